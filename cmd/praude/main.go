@@ -1,7 +1,13 @@
 package main
 
-import "github.com/mistakeknot/praude/internal/cli"
+import (
+	"os"
+
+	"github.com/mistakeknot/praude/internal/cli"
+)
 
 func main() {
-	_ = cli.Execute()
+	if err := cli.Execute(); err != nil {
+		os.Exit(1)
+	}
 }
@@ -4,14 +4,67 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 )
 
 type Profile struct {
-	Command string
-	Args    []string
+	Command    string
+	Args       []string
+	Env        map[string]string
+	Subagent   bool
+	Brief      string
+	Workdir    string
+	Mode       string
+	Sandbox    string
+	Timeout    time.Duration
+	MaxRetries int
+	ScanBudget int
 }
 
-var lookPath = exec.LookPath
+type Vars struct {
+	Brief  string
+	Spec   string
+	ID     string
+	Output string
+	Root   string
+}
+
+const FakeAgent = "fake"
+
+const DefaultScanBudget = 16 * 1024
+
+const (
+	SubagentEnv = "PRAUDE_SUBAGENT=1"
+	BriefEnvVar = "PRAUDE_BRIEF"
+)
+
+const (
+	BriefArg   = "arg"
+	BriefStdin = "stdin"
+	BriefEnv   = "env"
+)
+
+const (
+	ModeHeadless    = "headless"
+	ModeInteractive = "interactive"
+)
+
+const (
+	SandboxWorktree = "worktree"
+	SandboxCopy     = "copy"
+)
+
+var Placeholders = []string{"brief", "spec", "id", "output", "root", "brief_contents"}
+
+var (
+	lookPath        = exec.LookPath
+	executable      = os.Executable
+	placeholderExpr = regexp.MustCompile(`\{([a-z_]+)\}`)
+)
 
 func Resolve(cfg map[string]Profile, name string) (Profile, error) {
 	p, ok := cfg[name]
@@ -21,28 +74,186 @@ func Resolve(cfg map[string]Profile, name string) (Profile, error) {
 	return p, nil
 }
 
-func Launch(p Profile, briefPath string) error {
-	return launchWithEnv(p, briefPath, nil)
+func FakeProfile() Profile {
+	self, err := executable()
+	if err != nil {
+		self = "praude"
+	}
+	return Profile{Command: self, Args: []string{"agent", "fake", "--root", "{root}", "{brief}", "{id}", "{output}"}}
+}
+
+func Available(p Profile) error {
+	_, err := lookPath(p.Command)
+	return err
+}
+
+func (p Profile) Validate() error {
+	switch p.Brief {
+	case "", BriefArg, BriefStdin, BriefEnv:
+	default:
+		return fmt.Errorf("invalid brief delivery %q (use %s, %s or %s)", p.Brief, BriefArg, BriefStdin, BriefEnv)
+	}
+	switch p.Mode {
+	case "", ModeHeadless, ModeInteractive:
+	default:
+		return fmt.Errorf("invalid mode %q (use %s or %s)", p.Mode, ModeHeadless, ModeInteractive)
+	}
+	switch p.Sandbox {
+	case "", SandboxWorktree, SandboxCopy:
+	default:
+		return fmt.Errorf("invalid sandbox %q (use %s or %s)", p.Sandbox, SandboxWorktree, SandboxCopy)
+	}
+	if p.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative")
+	}
+	if p.MaxRetries < 0 {
+		return fmt.Errorf("max_retries must not be negative")
+	}
+	if p.Interactive() && p.Brief == BriefStdin {
+		return fmt.Errorf("interactive agents cannot read the brief from stdin")
+	}
+	return CheckPlaceholders(p.Placeholders())
+}
+
+func (p Profile) Interactive() bool {
+	return p.Mode == ModeInteractive
+}
+
+func (p Profile) ScanBytes() int {
+	switch {
+	case p.ScanBudget < 0:
+		return 0
+	case p.ScanBudget == 0:
+		return DefaultScanBudget
+	}
+	return p.ScanBudget
+}
+
+func (p Profile) BriefDelivery() string {
+	if p.Brief == "" {
+		return BriefArg
+	}
+	return p.Brief
+}
+
+func CheckPlaceholders(values []string) error {
+	for _, value := range values {
+		for _, match := range placeholderExpr.FindAllStringSubmatch(value, -1) {
+			if !knownPlaceholder(match[1]) {
+				return fmt.Errorf("unknown placeholder %s (known: %s)", match[0], strings.Join(Placeholders, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+func (p Profile) Placeholders() []string {
+	values := append([]string{}, p.Args...)
+	if p.Workdir != "" {
+		values = append(values, p.Workdir)
+	}
+	for _, key := range envKeys(p.Env) {
+		values = append(values, p.Env[key])
+	}
+	return values
+}
+
+func CommandLine(p Profile, vars Vars) ([]string, error) {
+	values, err := placeholderValues(p, vars)
+	if err != nil {
+		return nil, err
+	}
+	argv := []string{p.Command}
+	used := false
+	for _, arg := range p.Args {
+		if placeholderExpr.MatchString(arg) {
+			used = true
+		}
+		argv = append(argv, expand(arg, values))
+	}
+	if !used && p.BriefDelivery() == BriefArg {
+		argv = append(argv, vars.Brief)
+	}
+	return argv, nil
+}
+
+func Environ(p Profile, vars Vars) ([]string, error) {
+	values, err := placeholderValues(p, vars)
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, key := range envKeys(p.Env) {
+		out = append(out, key+"="+expand(p.Env[key], values))
+	}
+	if p.BriefDelivery() == BriefEnv {
+		out = append(out, BriefEnvVar+"="+vars.Brief)
+	}
+	if p.Subagent {
+		out = append(out, SubagentEnv)
+	}
+	return out, nil
+}
+
+func Workdir(p Profile, vars Vars) (string, error) {
+	if p.Workdir == "" {
+		return vars.Root, nil
+	}
+	values, err := placeholderValues(p, vars)
+	if err != nil {
+		return "", err
+	}
+	dir := expand(p.Workdir, values)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(vars.Root, dir)
+	}
+	return dir, nil
+}
+
+func placeholderValues(p Profile, vars Vars) (map[string]string, error) {
+	values := map[string]string{
+		"brief":  vars.Brief,
+		"spec":   vars.Spec,
+		"id":     vars.ID,
+		"output": vars.Output,
+		"root":   vars.Root,
+	}
+	for _, value := range p.Placeholders() {
+		if strings.Contains(value, "{brief_contents}") {
+			raw, err := os.ReadFile(vars.Brief)
+			if err != nil {
+				return nil, err
+			}
+			values["brief_contents"] = string(raw)
+			break
+		}
+	}
+	return values, nil
 }
 
-func LaunchSubagent(p Profile, briefPath string) error {
-	return launchWithEnv(p, briefPath, []string{"PRAUDE_SUBAGENT=1"})
+func expand(value string, values map[string]string) string {
+	return placeholderExpr.ReplaceAllStringFunc(value, func(token string) string {
+		if out, ok := values[token[1:len(token)-1]]; ok {
+			return out
+		}
+		return token
+	})
 }
 
-func launchWithEnv(p Profile, briefPath string, extraEnv []string) error {
-	if _, err := lookPath(p.Command); err != nil {
-		return err
+func knownPlaceholder(name string) bool {
+	for _, known := range Placeholders {
+		if name == known {
+			return true
+		}
 	}
-	cmd := buildCommand(p, briefPath, extraEnv)
-	return cmd.Start()
+	return false
 }
 
-func buildCommand(p Profile, briefPath string, extraEnv []string) *exec.Cmd {
-	args := append([]string{}, p.Args...)
-	args = append(args, briefPath)
-	cmd := exec.Command(p.Command, args...)
-	if len(extraEnv) > 0 {
-		cmd.Env = append(os.Environ(), extraEnv...)
+func envKeys(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
 	}
-	return cmd
+	sort.Strings(keys)
+	return keys
 }
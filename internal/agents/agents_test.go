@@ -1,6 +1,8 @@
 package agents
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -12,23 +14,89 @@ func TestResolveProfileMissing(t *testing.T) {
 	}
 }
 
-func TestBuildCommandSetsEnvForSubagent(t *testing.T) {
-	p := Profile{Command: "echo", Args: []string{"hello"}}
-	cmd := buildCommand(p, "brief.md", []string{"PRAUDE_SUBAGENT=1"})
-	if len(cmd.Env) == 0 {
-		t.Fatalf("expected env set")
-	}
-	found := false
-	for _, entry := range cmd.Env {
-		if entry == "PRAUDE_SUBAGENT=1" {
-			found = true
-			break
-		}
+func TestCommandLineExpandsPlaceholders(t *testing.T) {
+	brief := filepath.Join(t.TempDir(), "brief.md")
+	if err := os.WriteFile(brief, []byte("Do the thing"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	vars := Vars{Brief: brief, Spec: "specs/PRD-001.yaml", ID: "PRD-001", Output: "out.md", Root: "/repo"}
+	p := Profile{Command: "agent", Args: []string{"--prompt-file", "{brief}", "--spec={spec}", "{id}:{output}@{root}", "{brief_contents}"}}
+	argv, err := CommandLine(p, vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"agent", "--prompt-file", brief, "--spec=specs/PRD-001.yaml", "PRD-001:out.md@/repo", "Do the thing"}
+	if strings.Join(argv, "|") != strings.Join(want, "|") {
+		t.Fatalf("expected %v, got %v", want, argv)
+	}
+	argv, err = CommandLine(Profile{Command: "agent", Args: []string{"--headless"}}, vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if argv[len(argv)-1] != brief {
+		t.Fatalf("expected brief appended without placeholders, got %v", argv)
+	}
+	env, err := Environ(Profile{Env: map[string]string{"SPEC": "{spec}", "MODE": "pm"}}, vars)
+	if err != nil {
+		t.Fatal(err)
 	}
-	if !found {
-		t.Fatalf("expected PRAUDE_SUBAGENT in env")
+	if strings.Join(env, ",") != "MODE=pm,SPEC=specs/PRD-001.yaml" {
+		t.Fatalf("unexpected env %v", env)
+	}
+}
+
+func TestCheckPlaceholdersRejectsUnknown(t *testing.T) {
+	if err := CheckPlaceholders([]string{"{brief}", `{"json": true}`}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := CheckPlaceholders([]string{"--file={breif}"})
+	if err == nil || !strings.Contains(err.Error(), "{breif}") {
+		t.Fatalf("expected unknown placeholder error, got %v", err)
+	}
+}
+
+func TestProfileCapabilities(t *testing.T) {
+	vars := Vars{Brief: "brief.md", Root: "/repo"}
+	p := Profile{Command: "agent", Brief: BriefEnv, Subagent: true, Workdir: "sub/{id}"}
+	argv, err := CommandLine(p, vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(argv) != 1 {
+		t.Fatalf("expected brief not to be appended for env delivery, got %v", argv)
+	}
+	env, err := Environ(p, vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(env, ",") != BriefEnvVar+"=brief.md,"+SubagentEnv {
+		t.Fatalf("unexpected env %v", env)
+	}
+	dir, err := Workdir(p, Vars{Root: "/repo", ID: "PRD-001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dir != "/repo/sub/PRD-001" {
+		t.Fatalf("unexpected workdir %q", dir)
+	}
+	if dir, _ := Workdir(Profile{}, vars); dir != "/repo" {
+		t.Fatalf("expected root as default workdir, got %q", dir)
+	}
+}
+
+func TestProfileValidate(t *testing.T) {
+	for _, p := range []Profile{
+		{Brief: "pipe"},
+		{Mode: "background"},
+		{Brief: BriefStdin, Mode: ModeInteractive},
+		{Workdir: "{nope}"},
+		{Sandbox: "docker"},
+	} {
+		if err := p.Validate(); err == nil {
+			t.Fatalf("expected %+v to be invalid", p)
+		}
 	}
-	if got := strings.Join(cmd.Args, " "); !strings.Contains(got, "brief.md") {
-		t.Fatalf("expected brief path in args: %s", got)
+	if err := (Profile{Brief: BriefStdin, Mode: ModeHeadless, Sandbox: SandboxWorktree}).Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
 }
@@ -1,6 +1,31 @@
 package brief
 
-import "fmt"
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/project"
+	"github.com/mistakeknot/praude/internal/scan"
+	"github.com/mistakeknot/praude/internal/specs"
+	"github.com/mistakeknot/praude/internal/suggestions"
+)
+
+const (
+	Handoff  = "handoff"
+	Research = "research"
+	Suggest  = "suggest"
+)
+
+var Names = []string{Handoff, Research, Suggest}
+
+//go:embed templates/*.tmpl
+var defaults embed.FS
 
 type Input struct {
 	ID            string
@@ -9,22 +34,206 @@ type Input struct {
 	Requirements  []string
 	Acceptance    []string
 	ResearchFiles []string
+	Artifact      string
+	Context       []scan.Excerpt
+	Feedback      []Feedback
+	Rejected      string
+	Spec          string
+}
+
+type Feedback struct {
+	Section string
+	Reason  string
+}
+
+func (f Feedback) String() string {
+	return f.Section + ": " + f.Reason
+}
+
+var funcs = template.FuncMap{
+	"list":    list,
+	"section": section,
+	"indent":  indent,
+	"trim":    strings.TrimSpace,
+}
+
+func OverridesDir(root string) string {
+	return filepath.Join(project.RootDir(root), "templates", "briefs")
+}
+
+func Default(name string) ([]byte, error) {
+	return defaults.ReadFile("templates/" + name + ".tmpl")
+}
+
+func Load(root string) (*template.Template, error) {
+	set := template.New("briefs").Funcs(funcs).Option("missingkey=error")
+	for _, name := range Names {
+		raw, err := Default(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := set.New(name).Parse(string(raw)); err != nil {
+			return nil, fmt.Errorf("default %s brief: %w", name, err)
+		}
+	}
+	if root == "" {
+		return set, nil
+	}
+	for _, name := range Names {
+		path := filepath.Join(OverridesDir(root), name+".tmpl")
+		raw, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, err := set.New(name).Parse(string(raw)); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return set, nil
+}
+
+func Render(root, name string, in Input) (string, error) {
+	set, err := Load(root)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := set.ExecuteTemplate(&b, name, in); err != nil {
+		return "", fmt.Errorf("%s brief: %w", name, err)
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n", nil
 }
 
-func Compose(in Input) string {
-	return fmt.Sprintf(`PRD: %s
-Title: %s
+func FromSpec(spec specs.Spec, artifact string) Input {
+	acceptance := []string{}
+	for _, item := range spec.Acceptance {
+		if strings.TrimSpace(item.Description) != "" {
+			acceptance = append(acceptance, item.Description)
+		}
+	}
+	return Input{
+		ID:            spec.ID,
+		Title:         spec.Title,
+		Summary:       spec.Summary,
+		Requirements:  spec.Requirements,
+		Acceptance:    acceptance,
+		ResearchFiles: spec.Research,
+		Artifact:      artifact,
+	}
+}
+
+type Repo struct {
+	root    string
+	scanned bool
+	res     scan.Result
+}
+
+func NewRepo(root string) *Repo {
+	return &Repo{root: root}
+}
+
+func (r *Repo) Scan() scan.Result {
+	if !r.scanned {
+		r.res, _ = scan.ScanRepo(r.root, scan.Options{})
+		r.scanned = true
+	}
+	return r.res
+}
+
+func RepoContext(repo *Repo, spec specs.Spec, budget int) []scan.Excerpt {
+	if budget <= 0 {
+		return nil
+	}
+	hints := scan.Hints{Keywords: scan.Keywords(append([]string{spec.Title}, spec.Requirements...))}
+	for _, file := range spec.FilesToModify {
+		hints.Files = append(hints.Files, file.Path)
+	}
+	res := scan.Include(repo.Scan(), repo.root, hints.Files, scan.Options{})
+	return scan.Excerpts(res, hints, budget)
+}
 
-Summary:
-%s
+func WriteResearch(repo *Repo, id, artifact string, budget int, now time.Time) (string, error) {
+	spec, err := specs.LoadSpec(filepath.Join(project.SpecsDir(repo.root), id+".yaml"))
+	if err != nil {
+		return "", err
+	}
+	in := FromSpec(spec, artifact)
+	in.Context = RepoContext(repo, spec, budget)
+	return write(repo.root, Research, in, now)
+}
 
-Requirements:
-%v
+func WriteSuggest(repo *Repo, id, artifact string, budget int, now time.Time) (string, error) {
+	spec, err := specs.LoadSpec(filepath.Join(project.SpecsDir(repo.root), id+".yaml"))
+	if err != nil {
+		return "", err
+	}
+	in := FromSpec(spec, artifact)
+	in.Context = RepoContext(repo, spec, budget)
+	return write(repo.root, Suggest, in, now)
+}
 
-Acceptance Criteria:
-%v
+func WriteFeedback(repo *Repo, id, rejectedPath, artifact string, reasons map[string]string, budget int, now time.Time) (string, error) {
+	specPath := filepath.Join(project.SpecsDir(repo.root), id+".yaml")
+	spec, err := specs.LoadSpec(specPath)
+	if err != nil {
+		return "", err
+	}
+	specRaw, err := os.ReadFile(specPath)
+	if err != nil {
+		return "", err
+	}
+	rejected, err := os.ReadFile(rejectedPath)
+	if err != nil {
+		return "", err
+	}
+	in := FromSpec(spec, artifact)
+	in.Context = RepoContext(repo, spec, budget)
+	for _, key := range suggestions.SectionKeys() {
+		if reason := strings.TrimSpace(reasons[key]); reason != "" {
+			in.Feedback = append(in.Feedback, Feedback{Section: suggestions.SectionTitle(key), Reason: reason})
+		}
+	}
+	in.Rejected, in.Spec = string(rejected), string(specRaw)
+	return write(repo.root, Suggest, in, now)
+}
+
+func write(root, name string, in Input, now time.Time) (string, error) {
+	content, err := Render(root, name, in)
+	if err != nil {
+		return "", err
+	}
+	dir := project.BriefsDir(root)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := project.StampedPath(dir, in.ID, now)
+	return path, os.WriteFile(path, []byte(content), 0o644)
+}
+
+func list(items any) string {
+	value := reflect.ValueOf(items)
+	if value.Kind() != reflect.Slice || value.Len() == 0 {
+		return "- (none)"
+	}
+	lines := make([]string, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		lines = append(lines, "- "+strings.TrimSpace(fmt.Sprint(value.Index(i).Interface())))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func section(title, body string) string {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		body = "(none)"
+	}
+	return title + ":\n" + body
+}
 
-Research:
-%v
-`, in.ID, in.Title, in.Summary, in.Requirements, in.Acceptance, in.ResearchFiles)
+func indent(n int, text string) string {
+	pad := strings.Repeat(" ", n)
+	return pad + strings.ReplaceAll(text, "\n", "\n"+pad)
 }
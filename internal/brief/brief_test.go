@@ -1,13 +1,141 @@
 package brief
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
-func TestBriefIncludesSummary(t *testing.T) {
-	b := Compose(Input{ID: "PRD-001", Title: "T", Summary: "S"})
-	if b == "" || !strings.Contains(b, "Summary:") || !strings.Contains(b, "S") {
-		t.Fatalf("expected summary in brief")
+func TestRenderHandoffListsItemsOnePerLine(t *testing.T) {
+	b, err := Render("", Handoff, Input{ID: "PRD-001", Title: "T", Summary: "S", Requirements: []string{"REQ-001: foo", "REQ-002: bar"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"PRD: PRD-001", "Summary:\nS", "Requirements:\n- REQ-001: foo\n- REQ-002: bar\n", "Acceptance Criteria:\n- (none)"} {
+		if !strings.Contains(b, want) {
+			t.Fatalf("expected %q in brief, got %q", want, b)
+		}
+	}
+}
+
+func TestRenderResearchIncludesOssScan(t *testing.T) {
+	b, err := Render("", Research, Input{ID: "PRD-001", Title: "Alpha", Acceptance: []string{"Do thing"}, Artifact: ".praude/research/PRD-001-20260115-120000.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"OSS project scan", "evidence refs", "- Do thing", "research template at:\n  .praude/research/PRD-001-20260115-120000.md"} {
+		if !strings.Contains(b, want) {
+			t.Fatalf("expected %q in brief, got %q", want, b)
+		}
+	}
+}
+
+func TestRenderSuggestIncludesFeedbackOnlyWhenGiven(t *testing.T) {
+	plain, err := Render("", Suggest, Input{ID: "PRD-001", Artifact: "out.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(plain, "Reviewer feedback") || !strings.HasSuffix(plain, "  out.md\n") {
+		t.Fatalf("expected plain suggestion brief, got %q", plain)
+	}
+	b, err := Render("", Suggest, Input{ID: "PRD-001", Artifact: "out.md", Feedback: []Feedback{{Section: "Summary", Reason: "too vague"}}, Rejected: "old suggestion", Spec: "id: PRD-001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"- Summary: too vague", "Rejected suggestions:\nold suggestion", "Current spec:\nid: PRD-001"} {
+		if !strings.Contains(b, want) {
+			t.Fatalf("expected %q in feedback, got %q", want, b)
+		}
+	}
+}
+
+func TestProjectOverridesReplaceNamedTemplates(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(OverridesDir(root), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	override := "PRD {{.ID}} ({{.Title}})\n{{list .Requirements}}\n"
+	if err := os.WriteFile(filepath.Join(OverridesDir(root), "handoff.tmpl"), []byte(override), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	b, err := Render(root, Research, Input{ID: "PRD-001", Title: "Alpha", Requirements: []string{"REQ-001: foo"}, Artifact: "out.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(b, "PRD PRD-001 (Alpha)\n- REQ-001: foo") || !strings.Contains(b, "research template at:") {
+		t.Fatalf("expected overridden handoff inside the default research brief, got %q", b)
+	}
+	if err := os.WriteFile(filepath.Join(OverridesDir(root), "suggest.tmpl"), []byte("{{.Missing}}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Render(root, Suggest, Input{ID: "PRD-001"}); err == nil || !strings.Contains(err.Error(), "suggest brief") {
+		t.Fatalf("expected a render error naming the brief, got %v", err)
+	}
+}
+
+func TestWriteSuggestIncludesBudgetedRepoContext(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	spec := "id: \"PRD-001\"\ntitle: \"Invoices\"\nsummary: \"S\"\nrequirements:\n  - \"REQ-001: Export invoices\"\nfiles_to_modify:\n  - action: \"modify\"\n    path: \"billing/export.go\"\n"
+	if err := os.WriteFile(filepath.Join(root, ".praude", "specs", "PRD-001.yaml"), []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "billing"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"billing/export.go": "package billing\n",
+		"README.md":         "# Billing\n" + strings.Repeat("filler line\n", 50),
+		"unrelated.txt":     "nothing here\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	repo := NewRepo(root)
+	path, err := WriteSuggest(repo, "PRD-001", "out.md", 200, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := string(raw)
+	for _, want := range []string{"Repository context (ranked excerpts):", "--- billing/export.go (file to modify)\npackage billing", "--- README.md (readme, truncated)"} {
+		if !strings.Contains(b, want) {
+			t.Fatalf("expected %q in brief, got %q", want, b)
+		}
+	}
+	if strings.Contains(b, "unrelated.txt") || strings.Index(b, "billing/export.go (") > strings.Index(b, "README.md (") {
+		t.Fatalf("expected ranked context without unrelated files, got %q", b)
+	}
+	path, err = WriteSuggest(repo, "PRD-001", "out.md", 0, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw, _ := os.ReadFile(path); strings.Contains(string(raw), "Repository context") {
+		t.Fatalf("expected no context without a budget")
+	}
+	if err := os.WriteFile(filepath.Join(root, "invoices.go"), []byte("package invoices\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		repo *Repo
+		want bool
+	}{{repo, false}, {NewRepo(root), true}} {
+		path, err := WriteSuggest(tc.repo, "PRD-001", "out.md", 1000, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, _ := os.ReadFile(path)
+		if got := strings.Contains(string(raw), "--- invoices.go"); got != tc.want {
+			t.Fatalf("expected a repo to be scanned once per command (want new file %v), got %q", tc.want, raw)
+		}
 	}
 }
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/fakeagent"
+	"github.com/spf13/cobra"
+)
+
+func AgentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "agent",
+		Short: "Built-in agents",
+	}
+	cmd.AddCommand(agentFakeCmd())
+	return cmd
+}
+
+func agentFakeCmd() *cobra.Command {
+	var root string
+	cmd := &cobra.Command{
+		Use:   "fake <brief> <id> <output>",
+		Short: "Fill a research or suggestions template with deterministic content",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if root == "" {
+				cwd, err := os.Getwd()
+				if err != nil {
+					return err
+				}
+				root = cwd
+			}
+			if err := fakeagent.Fill(root, args[0], args[1], args[2], time.Now()); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Filled %s\n", filepath.Base(args[2]))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&root, "root", "", "Project root holding the .praude directory (default: current directory)")
+	return cmd
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/runs"
+	"github.com/mistakeknot/praude/internal/specs"
+	"github.com/spf13/cobra"
+)
+
+const reexecEnv = "PRAUDE_TEST_REEXEC"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(reexecEnv) == "1" {
+		root := &cobra.Command{Use: "praude", SilenceUsage: true}
+		root.AddCommand(RunsCmd(), AgentCmd())
+		if err := root.Execute(); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestFakeAgentDrivesResearchIngestAndApply(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".praude", "config.toml"), []byte("validation_mode = \"hard\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	specPath := filepath.Join(root, ".praude", "specs", "PRD-001.yaml")
+	spec := `id: "PRD-001"
+title: "Alpha"
+summary: "Summary"
+requirements:
+  - "REQ-001: Import"
+`
+	if err := os.WriteFile(specPath, []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(reexecEnv, "1")
+	oldPoll := waitPoll
+	waitPoll = 20 * time.Millisecond
+	defer func() { waitPoll = oldPoll }()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	steps := []struct {
+		cmd  func() *cobra.Command
+		args []string
+	}{
+		{ResearchCmd, []string{"PRD-001", "--agent", "fake", "--wait"}},
+		{ResearchCmd, []string{"ingest", "PRD-001"}},
+		{SuggestCmd, []string{"PRD-001", "--agent", "fake", "--wait"}},
+		{SuggestionsCmd, []string{"apply", "PRD-001", "--all"}},
+	}
+	for _, step := range steps {
+		cmd := step.cmd()
+		cmd.SetArgs(step.args)
+		buf := bytes.NewBuffer(nil)
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("%v: %v\n%s", step.args, err, buf.String())
+		}
+		if step.args[0] == "PRD-001" && !strings.Contains(buf.String(), " succeeded") {
+			t.Fatalf("%v: expected the fake agent run to succeed, got:\n%s", step.args, buf.String())
+		}
+	}
+	all, err := runs.List(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 || all[0].Status != runs.StatusSucceeded || all[1].Status != runs.StatusSucceeded || all[0].SupervisorPID == os.Getpid() {
+		t.Fatalf("expected two supervised fake runs, got %+v", all)
+	}
+	got, err := specs.LoadSpec(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.MarketResearch) != 1 || len(got.CompetitiveLandscape) != 1 || len(got.OSSLandscape) != 1 {
+		t.Fatalf("expected ingested research, got %+v", got)
+	}
+	if len(got.Requirements) != 2 || !strings.HasPrefix(got.Requirements[1], "REQ-002:") {
+		t.Fatalf("expected applied requirement, got %v", got.Requirements)
+	}
+	if len(got.CriticalUserJourneys) != 1 || got.CriticalUserJourneys[0].LinkedRequirements[0] != "REQ-002" {
+		t.Fatalf("expected applied CUJ, got %+v", got.CriticalUserJourneys)
+	}
+	raw, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := specs.Validate(raw, specs.ValidationOptions{Mode: specs.ValidationHard, Root: root})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Errors) != 0 {
+		t.Fatalf("expected a valid spec, got %v", res.Errors)
+	}
+}
@@ -9,9 +9,11 @@ import (
 	"time"
 
 	"github.com/mistakeknot/praude/internal/agents"
+	"github.com/mistakeknot/praude/internal/brief"
 	"github.com/mistakeknot/praude/internal/config"
 	"github.com/mistakeknot/praude/internal/project"
 	"github.com/mistakeknot/praude/internal/research"
+	"github.com/mistakeknot/praude/internal/runs"
 	"github.com/mistakeknot/praude/internal/scan"
 	"github.com/mistakeknot/praude/internal/specs"
 	"github.com/spf13/cobra"
@@ -38,10 +40,10 @@ func InterviewCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			repo := brief.NewRepo(root)
 			summary := ""
 			if scanNow {
-				res, _ := scan.ScanRepo(root, scan.Options{})
-				summary = renderScanSummary(res)
+				summary = renderScanSummary(repo.Scan())
 			}
 			draft := buildDraftSpec(summary)
 			fmt.Fprintln(out, "Draft PRD ready.")
@@ -91,30 +93,19 @@ func InterviewCmd() *cobra.Command {
 				return nil
 			}
 			now := time.Now()
-			researchDir := project.ResearchDir(root)
-			if err := os.MkdirAll(researchDir, 0o755); err != nil {
-				return err
-			}
-			researchPath, err := research.Create(researchDir, id, now)
+			researchPath, err := research.CreateForSpec(root, id, now)
 			if err != nil {
 				return err
 			}
-			briefPath, err := writeResearchBrief(root, id, researchPath, now)
+			profile, err := agents.Resolve(cfg.AgentProfiles(), agent)
 			if err != nil {
 				return err
 			}
-			profile, err := agents.Resolve(agentProfiles(cfg), agent)
+			briefPath, err := brief.WriteResearch(repo, id, researchPath, profile.ScanBytes(), now)
 			if err != nil {
 				return err
 			}
-			launcher := launchAgent
-			if isClaudeProfile(agent, profile) {
-				launcher = launchSubagent
-			}
-			if err := launcher(profile, briefPath); err != nil {
-				fmt.Fprintf(out, "agent not found; brief at %s\n", briefPath)
-				return nil
-			}
+			startRun(out, root, runs.Request{Kind: "research", SpecID: id, Agent: agent, Profile: profile, Brief: briefPath, Target: researchPath})
 			return nil
 		},
 	}
@@ -198,7 +189,9 @@ func writeSpec(root string, spec specs.Spec) (string, string, []string, error) {
 	if err := os.WriteFile(path, raw, 0o644); err != nil {
 		return path, id, nil, err
 	}
-	res, err := specs.Validate(raw, specs.ValidationOptions{Mode: specs.ValidationSoft, Root: root})
+	opts := config.LoadValidationOptions(root)
+	opts.Mode = specs.ValidationSoft
+	res, err := specs.Validate(raw, opts)
 	if err != nil {
 		return path, id, nil, err
 	}
@@ -7,7 +7,7 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/mistakeknot/praude/internal/agents"
+	"github.com/mistakeknot/praude/internal/runs"
 )
 
 func TestInterviewCommandCreatesSpec(t *testing.T) {
@@ -88,20 +88,12 @@ args = []
 		t.Fatal(err)
 	}
 	called := false
-	oldLaunch := launchAgent
-	oldSub := launchSubagent
-	launchAgent = func(p agents.Profile, briefPath string) error {
+	oldLaunch := launchRun
+	launchRun = func(root string, req runs.Request) (runs.Run, error) {
 		called = true
-		return nil
+		return runs.Run{ID: "run-1"}, nil
 	}
-	launchSubagent = func(p agents.Profile, briefPath string) error {
-		called = true
-		return nil
-	}
-	defer func() {
-		launchAgent = oldLaunch
-		launchSubagent = oldSub
-	}()
+	defer func() { launchRun = oldLaunch }()
 	cwd, err := os.Getwd()
 	if err != nil {
 		t.Fatal(err)
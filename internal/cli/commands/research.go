@@ -4,25 +4,30 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 
-	"github.com/mistakeknot/praude/internal/agents"
 	"github.com/mistakeknot/praude/internal/brief"
 	"github.com/mistakeknot/praude/internal/config"
+	"github.com/mistakeknot/praude/internal/git"
 	"github.com/mistakeknot/praude/internal/project"
 	"github.com/mistakeknot/praude/internal/research"
+	"github.com/mistakeknot/praude/internal/runs"
 	"github.com/mistakeknot/praude/internal/specs"
 	"github.com/spf13/cobra"
 )
 
 func ResearchCmd() *cobra.Command {
 	var agent string
+	var agentNames []string
+	var wait, all bool
 	cmd := &cobra.Command{
 		Use:   "research <id>",
 		Short: "Create a research artifact for a PRD",
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if all == (len(args) == 1) {
+				return fmt.Errorf("pass a PRD id or --all")
+			}
 			root, err := os.Getwd()
 			if err != nil {
 				return err
@@ -31,78 +36,266 @@ func ResearchCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			profile, err := agents.Resolve(agentProfiles(cfg), agent)
+			choices, err := resolveAgents(cmd, cfg, agent, agentNames)
+			if err != nil {
+				return err
+			}
+			ids := args
+			if all {
+				all, err := specs.LoadAll(project.SpecsDir(root))
+				if err != nil {
+					return err
+				}
+				for _, spec := range all {
+					ids = append(ids, spec.ID)
+				}
+				if len(ids) == 0 {
+					return fmt.Errorf("no PRDs found")
+				}
+			}
+			out := cmd.OutOrStdout()
+			repo := brief.NewRepo(root)
+			var started []runs.Run
+			for _, id := range ids {
+				now := time.Now()
+				for _, choice := range choices {
+					path, err := research.CreateForSpec(root, id, now)
+					if err != nil {
+						return err
+					}
+					fmt.Fprintln(out, filepath.Base(path))
+					briefPath, err := brief.WriteResearch(repo, id, path, choice.Profile.ScanBytes(), now)
+					if err != nil {
+						return err
+					}
+					if run, ok := startRun(out, root, runs.Request{Kind: "research", SpecID: id, Agent: choice.Name, Profile: choice.Profile, Brief: briefPath, Target: path}); ok {
+						started = append(started, run)
+					}
+				}
+			}
+			if wait {
+				return waitForRuns(out, root, started)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&agent, "agent", "codex", "Agent profile to use")
+	cmd.Flags().StringSliceVar(&agentNames, "agents", nil, "Fan out to several agent profiles, each into its own artifact (e.g. codex,claude)")
+	cmd.Flags().BoolVar(&all, "all", false, "Research every PRD; runs beyond the concurrency limit are queued")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait for the agent and report the ingestable findings")
+	cmd.AddCommand(researchStaleCmd())
+	cmd.AddCommand(researchIngestCmd())
+	cmd.AddCommand(researchListCmd())
+	cmd.AddCommand(researchGCCmd())
+	return cmd
+}
+
+func researchStaleCmd() *cobra.Command {
+	var maxAge int
+	cmd := &cobra.Command{
+		Use:   "stale",
+		Short: "List stale market research claims across all PRDs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			cfg, err := config.LoadFromRoot(root)
+			if err != nil {
+				return err
+			}
+			days := cfg.Research.MaxAgeDays
+			if cmd.Flags().Changed("max-age-days") {
+				days = maxAge
+			}
+			all, err := specs.LoadAll(project.SpecsDir(root))
 			if err != nil {
 				return err
 			}
-			id := args[0]
 			now := time.Now()
-			researchDir := project.ResearchDir(root)
-			if err := os.MkdirAll(researchDir, 0o755); err != nil {
+			out := cmd.OutOrStdout()
+			found := 0
+			for _, spec := range all {
+				for _, claim := range specs.StaleClaims(spec, now, days) {
+					age := "undated"
+					if claim.AgeDays >= 0 {
+						age = fmt.Sprintf("%dd", claim.AgeDays)
+					}
+					fmt.Fprintf(out, "%s\t%s\t%s\t%s\t%s\n", claim.SpecID, claim.ID, claim.Date, age, claim.Claim)
+					found++
+				}
+			}
+			if found == 0 {
+				fmt.Fprintln(out, "No stale claims.")
+			}
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&maxAge, "max-age-days", 0, "Override the configured max claim age; 0 disables (undated claims are still listed)")
+	return cmd
+}
+
+func researchIngestCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ingest <id> [file]",
+		Short: "Merge a completed research artifact into a PRD",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := os.Getwd()
+			if err != nil {
 				return err
 			}
-			path, err := research.Create(researchDir, id, now)
+			id := args[0]
+			specPath, err := resolveSpecPath(project.SpecsDir(root), id)
 			if err != nil {
 				return err
 			}
-			fmt.Fprintln(cmd.OutOrStdout(), filepath.Base(path))
-			briefPath, err := writeResearchBrief(root, id, path, now)
+			artifactPath, err := resolveResearchPath(root, id, args[1:])
 			if err != nil {
 				return err
 			}
-			launcher := launchAgent
-			if isClaudeProfile(agent, profile) {
-				launcher = launchSubagent
+			raw, err := os.ReadFile(artifactPath)
+			if err != nil {
+				return err
+			}
+			spec, err := specs.LoadSpec(specPath)
+			if err != nil {
+				return err
+			}
+			stats, err := research.Merge(&spec, research.Parse(raw), research.RelPath(filepath.Base(artifactPath)))
+			if err != nil {
+				return fmt.Errorf("%s: %w", filepath.Base(artifactPath), err)
+			}
+			if err := specs.SaveSpec(specPath, spec); err != nil {
+				return err
 			}
-			if err := launcher(profile, briefPath); err != nil {
-				fmt.Fprintf(cmd.OutOrStdout(), "agent not found; brief at %s\n", briefPath)
+			if err := git.EnsureRepo(root); err == nil {
+				_ = git.CommitFiles(root, []string{specPath}, "chore(praude): ingest research "+id)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Ingested %s into %s\n", filepath.Base(artifactPath), id)
+			fmt.Fprintf(cmd.OutOrStdout(), "Market: %d added, %d updated\n", stats.MarketAdded, stats.MarketUpdated)
+			fmt.Fprintf(cmd.OutOrStdout(), "Competitive: %d added, %d updated\n", stats.CompetitorAdded, stats.CompetitorUpdated)
+			fmt.Fprintf(cmd.OutOrStdout(), "OSS: %d added, %d updated\n", stats.OSSAdded, stats.OSSUpdated)
+			return nil
+		},
+	}
+}
+
+func researchListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list <id>",
+		Short: "List research artifacts for a PRD",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			id := args[0]
+			specPath, err := resolveSpecPath(project.SpecsDir(root), id)
+			if err != nil {
+				return err
+			}
+			spec, err := specs.LoadSpec(specPath)
+			if err != nil {
+				return err
+			}
+			artifacts, err := research.List(project.ResearchDir(root), id, spec)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if len(artifacts) == 0 {
+				fmt.Fprintf(out, "No research for %s\n", id)
 				return nil
 			}
+			for _, artifact := range artifacts {
+				registered := "unregistered"
+				if artifact.Registered {
+					registered = "registered"
+				}
+				fmt.Fprintf(out, "%s\t%s\t%s\n", artifact.Name, artifact.Status, registered)
+			}
 			return nil
 		},
 	}
-	cmd.Flags().StringVar(&agent, "agent", "codex", "Agent profile to use")
-	return cmd
 }
 
-func writeResearchBrief(root, id, researchPath string, now time.Time) (string, error) {
-	briefsDir := project.BriefsDir(root)
-	if err := os.MkdirAll(briefsDir, 0o755); err != nil {
-		return "", err
+func researchGCCmd() *cobra.Command {
+	var dryRun bool
+	var minAge time.Duration
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Archive unreferenced or never-filled research artifacts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			all, err := specs.LoadAll(project.SpecsDir(root))
+			if err != nil {
+				return err
+			}
+			unused, err := research.Unused(project.ResearchDir(root), all, minAge, time.Now())
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if len(unused) == 0 {
+				fmt.Fprintln(out, "No unused research artifacts.")
+				return nil
+			}
+			changed := []string{project.ResearchDir(root)}
+			for _, artifact := range unused {
+				reason := "unreferenced"
+				if artifact.Registered {
+					reason = string(artifact.Status)
+				}
+				if dryRun {
+					fmt.Fprintf(out, "would archive %s (%s)\n", artifact.Name, reason)
+					continue
+				}
+				if _, err := research.Archive(root, artifact); err != nil {
+					return err
+				}
+				if artifact.Registered {
+					changed = append(changed, filepath.Join(project.SpecsDir(root), artifact.SpecID+".yaml"))
+				}
+				fmt.Fprintf(out, "archived %s (%s)\n", artifact.Name, reason)
+			}
+			if !dryRun {
+				if err := git.EnsureRepo(root); err == nil {
+					_ = git.CommitFiles(root, changed, "chore(praude): archive unused research")
+				}
+			}
+			return nil
+		},
 	}
-	stamp := now.UTC().Format("20060102-150405")
-	briefPath := filepath.Join(briefsDir, id+"-"+stamp+".md")
-	specPath := filepath.Join(project.SpecsDir(root), id+".yaml")
-	spec, err := specs.LoadSpec(specPath)
-	if err != nil {
-		return "", err
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List artifacts without archiving them")
+	cmd.Flags().DurationVar(&minAge, "min-age", 24*time.Hour, "Skip artifacts modified more recently than this")
+	return cmd
+}
+
+func resolveResearchPath(root, id string, args []string) (string, error) {
+	dir := project.ResearchDir(root)
+	if len(args) == 0 {
+		return research.Latest(dir, id)
 	}
-	acceptance := []string{}
-	for _, item := range spec.Acceptance {
-		if strings.TrimSpace(item.Description) != "" {
-			acceptance = append(acceptance, item.Description)
+	path := args[0]
+	if _, err := os.Stat(path); err != nil {
+		path = filepath.Join(dir, filepath.Base(args[0]))
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("research file not found: %s", args[0])
 		}
 	}
-	content := buildResearchBrief(spec, researchPath, acceptance)
-	if err := os.WriteFile(briefPath, []byte(content), 0o644); err != nil {
+	abs, err := filepath.Abs(path)
+	if err != nil {
 		return "", err
 	}
-	return briefPath, nil
-}
-
-func buildResearchBrief(spec specs.Spec, researchPath string, acceptance []string) string {
-	base := brief.Compose(brief.Input{
-		ID:            spec.ID,
-		Title:         spec.Title,
-		Summary:       spec.Summary,
-		Requirements:  spec.Requirements,
-		Acceptance:    acceptance,
-		ResearchFiles: spec.Research,
-	})
-	instructions := "\n\nInstructions:\n" +
-		"- Fill in market research and competitive landscape sections.\n" +
-		"- Include an OSS project scan with evidence refs.\n" +
-		"- Use evidence refs for all claims.\n" +
-		"- Write results into the research template at:\n  " + researchPath + "\n"
-	return base + instructions
+	if filepath.Dir(abs) != filepath.Clean(dir) {
+		return "", fmt.Errorf("research file must be in %s: %s", research.RelPath(""), args[0])
+	}
+	return path, nil
 }
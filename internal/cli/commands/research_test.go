@@ -6,8 +6,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
-	"github.com/mistakeknot/praude/internal/agents"
+	"github.com/mistakeknot/praude/internal/runs"
 	"github.com/mistakeknot/praude/internal/specs"
 )
 
@@ -67,6 +68,13 @@ args = []
 	if _, err := os.Stat(filepath.Join(root, ".praude", "research", out)); err != nil {
 		t.Fatalf("expected research file created: %v", err)
 	}
+	registered, err := specs.LoadSpec(filepath.Join(root, ".praude", "specs", "PRD-001.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(registered.Research) != 1 || !strings.HasSuffix(registered.Research[0], out) {
+		t.Fatalf("expected research artifact registered, got %v", registered.Research)
+	}
 	briefsDir := filepath.Join(root, ".praude", "briefs")
 	entries, err := os.ReadDir(briefsDir)
 	if err != nil {
@@ -80,7 +88,7 @@ args = []
 	}
 }
 
-func TestResearchCommandUsesSubagentForClaude(t *testing.T) {
+func TestResearchCommandUsesSubagentFromProfile(t *testing.T) {
 	root := t.TempDir()
 	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
 		t.Fatal(err)
@@ -93,6 +101,7 @@ func TestResearchCommandUsesSubagentForClaude(t *testing.T) {
 [agents.claude]
 command = "claude"
 args = []
+subagent = true
 `
 	if err := os.WriteFile(filepath.Join(root, ".praude", "config.toml"), []byte(cfg), 0o644); err != nil {
 		t.Fatal(err)
@@ -111,20 +120,16 @@ acceptance_criteria:
 	}
 	calledSub := false
 	calledAgent := false
-	oldSub := launchSubagent
-	oldAgent := launchAgent
-	launchSubagent = func(p agents.Profile, briefPath string) error {
-		calledSub = true
-		return nil
-	}
-	launchAgent = func(p agents.Profile, briefPath string) error {
-		calledAgent = true
-		return nil
-	}
-	defer func() {
-		launchSubagent = oldSub
-		launchAgent = oldAgent
-	}()
+	oldLaunch := launchRun
+	launchRun = func(root string, req runs.Request) (runs.Run, error) {
+		if req.Profile.Subagent {
+			calledSub = true
+		} else {
+			calledAgent = true
+		}
+		return runs.Run{ID: "run-1"}, nil
+	}
+	defer func() { launchRun = oldLaunch }()
 	cwd, err := os.Getwd()
 	if err != nil {
 		t.Fatal(err)
@@ -149,18 +154,290 @@ acceptance_criteria:
 	}
 }
 
-func TestBuildResearchBriefIncludesOssScan(t *testing.T) {
-	spec := specs.Spec{
-		ID:           "PRD-001",
-		Title:        "Alpha",
-		Summary:      "Summary",
-		Requirements: []string{"REQ-1"},
-	}
-	brief := buildResearchBrief(spec, ".praude/research/PRD-001-20260115-120000.md", []string{"Do thing"})
-	if !strings.Contains(brief, "OSS project scan") {
-		t.Fatalf("expected OSS scan instructions")
-	}
-	if !strings.Contains(brief, "evidence refs") {
-		t.Fatalf("expected evidence refs instructions")
+func TestResearchStaleListsOldClaims(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".praude", "config.toml"), []byte("validation_mode = \"soft\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	spec := `id: "PRD-001"
+title: "Alpha"
+summary: "Summary"
+market_research:
+  - id: "MR-001"
+    claim: "Old claim"
+    confidence: "medium"
+    date: "2020-01-01"
+  - id: "MR-002"
+    claim: "Fresh claim"
+    confidence: "medium"
+    date: "` + time.Now().UTC().Format("2006-01-02") + `"
+`
+	if err := os.WriteFile(filepath.Join(root, ".praude", "specs", "PRD-001.yaml"), []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	cmd := ResearchCmd()
+	cmd.SetArgs([]string{"stale"})
+	buf := bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "PRD-001\tMR-001") {
+		t.Fatalf("expected stale claim listed, got %q", out)
+	}
+	if strings.Contains(out, "MR-002") {
+		t.Fatalf("expected fresh claim omitted, got %q", out)
+	}
+	cmd = ResearchCmd()
+	cmd.SetArgs([]string{"stale", "--max-age-days", "0"})
+	buf.Reset()
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "No stale claims.") {
+		t.Fatalf("expected staleness disabled by --max-age-days 0, got %q", buf.String())
+	}
+}
+
+func TestResearchStaleAndGCFailOnUnreadableSpecs(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".praude", "config.toml"), []byte("validation_mode = \"soft\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".praude", "specs", "PRD-001.yaml"), []byte("id: [unclosed\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{{"stale"}, {"gc"}} {
+		cmd := ResearchCmd()
+		cmd.SetArgs(args)
+		buf := bytes.NewBuffer(nil)
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "PRD-001.yaml") {
+			t.Fatalf("%s: expected spec load error, got %v (%q)", args[0], err, buf.String())
+		}
+	}
+}
+
+func TestResearchIngestMergesLatestArtifact(t *testing.T) {
+	root := t.TempDir()
+	researchDir := filepath.Join(root, ".praude", "research")
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(researchDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	spec := `id: "PRD-001"
+title: "Alpha"
+summary: "Summary"
+`
+	specPath := filepath.Join(root, ".praude", "specs", "PRD-001.yaml")
+	if err := os.WriteFile(specPath, []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	artifact := `# Research for PRD-001
+
+## Market Summary
+- Claim: "Demand is rising"
+  - confidence: "high"
+  - date: "2026-01-10"
+
+## Competitive Analysis
+- Claim: "Cheap and fast"
+  - name: "Acme"
+`
+	if err := os.WriteFile(filepath.Join(researchDir, "PRD-001-20260115-000000.md"), []byte(artifact), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	cmd := ResearchCmd()
+	cmd.SetArgs([]string{"ingest", "PRD-001"})
+	buf := bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := specs.LoadSpec(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updated.MarketResearch) != 1 || updated.MarketResearch[0].Claim != "Demand is rising" {
+		t.Fatalf("expected market claim ingested, got %+v", updated.MarketResearch)
+	}
+	if len(updated.CompetitiveLandscape) != 1 || updated.CompetitiveLandscape[0].Name != "Acme" {
+		t.Fatalf("expected competitor ingested, got %+v", updated.CompetitiveLandscape)
+	}
+	if len(updated.Research) != 1 || updated.Research[0] != ".praude/research/PRD-001-20260115-000000.md" {
+		t.Fatalf("expected research artifact registered, got %v", updated.Research)
+	}
+	if !strings.Contains(buf.String(), "Market: 1 added") {
+		t.Fatalf("expected ingest summary, got %q", buf.String())
+	}
+}
+
+func TestResearchListShowsArtifactStatus(t *testing.T) {
+	root := t.TempDir()
+	researchDir := filepath.Join(root, ".praude", "research")
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(researchDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	spec := `id: "PRD-001"
+title: "Alpha"
+summary: "Summary"
+research:
+  - ".praude/research/PRD-001-20260115-000000.md"
+`
+	if err := os.WriteFile(filepath.Join(root, ".praude", "specs", "PRD-001.yaml"), []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(researchDir, "PRD-001-20260115-000000.md"), []byte("## Market Summary\n- Claim: \"Real\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	cmd := ResearchCmd()
+	cmd.SetArgs([]string{"list", "PRD-001"})
+	buf := bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "PRD-001-20260115-000000.md\tfilled\tregistered") {
+		t.Fatalf("expected artifact status, got %q", buf.String())
+	}
+}
+
+func TestResearchAllLaunchesEverySpec(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"PRD-001", "PRD-002"} {
+		spec := "id: \"" + id + "\"\ntitle: \"Alpha\"\nsummary: \"Summary\"\n"
+		if err := os.WriteFile(filepath.Join(root, ".praude", "specs", id+".yaml"), []byte(spec), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := "[agents.codex]\ncommand = \"codex\"\n"
+	if err := os.WriteFile(filepath.Join(root, ".praude", "config.toml"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var launched []string
+	oldLaunch := launchRun
+	launchRun = func(root string, req runs.Request) (runs.Run, error) {
+		launched = append(launched, req.SpecID)
+		return runs.Run{ID: "run-" + req.SpecID}, nil
+	}
+	defer func() { launchRun = oldLaunch }()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	cmd := ResearchCmd()
+	cmd.SetArgs([]string{"--all"})
+	buf := bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(launched, ",") != "PRD-001,PRD-002" {
+		t.Fatalf("expected research for every spec, got %v", launched)
+	}
+	cmd = ResearchCmd()
+	cmd.SetArgs([]string{"PRD-001", "--all"})
+	cmd.SetOut(bytes.NewBuffer(nil))
+	cmd.SetErr(bytes.NewBuffer(nil))
+	if err := cmd.Execute(); err == nil {
+		t.Fatalf("expected id and --all to conflict")
+	}
+}
+
+func TestResearchIngestRejectsFilesOutsideResearchDir(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "research"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	specPath := filepath.Join(root, ".praude", "specs", "PRD-001.yaml")
+	if err := os.WriteFile(specPath, []byte("id: \"PRD-001\"\ntitle: \"Alpha\"\nsummary: \"Summary\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	artifact := "# Research for PRD-001\n\n## Market Summary\n- Claim: \"Demand is rising\"\n"
+	if err := os.WriteFile(filepath.Join(root, "notes.md"), []byte(artifact), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	cmd := ResearchCmd()
+	cmd.SetArgs([]string{"ingest", "PRD-001", "notes.md"})
+	buf := bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), ".praude/research") {
+		t.Fatalf("expected outside path rejected, got %v", err)
+	}
+	spec, err := specs.LoadSpec(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spec.MarketResearch) != 0 {
+		t.Fatalf("expected spec untouched, got %+v", spec.MarketResearch)
 	}
 }
@@ -1,11 +1,11 @@
 package commands
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/mistakeknot/praude/internal/agents"
 	"github.com/mistakeknot/praude/internal/config"
+	"github.com/mistakeknot/praude/internal/runs"
 	"github.com/spf13/cobra"
 )
 
@@ -25,18 +25,11 @@ func RunCmd() *cobra.Command {
 				return err
 			}
 			briefPath := args[0]
-			profile, err := agents.Resolve(agentProfiles(cfg), agent)
+			profile, err := agents.Resolve(cfg.AgentProfiles(), agent)
 			if err != nil {
 				return err
 			}
-			launcher := launchAgent
-			if isClaudeProfile(agent, profile) {
-				launcher = launchSubagent
-			}
-			if err := launcher(profile, briefPath); err != nil {
-				fmt.Fprintf(cmd.OutOrStdout(), "agent not found; brief at %s\n", briefPath)
-				return nil
-			}
+			startRun(cmd.OutOrStdout(), root, runs.Request{Kind: "run", SpecID: "", Agent: agent, Profile: profile, Brief: briefPath, Target: ""})
 			return nil
 		},
 	}
@@ -7,7 +7,7 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/mistakeknot/praude/internal/agents"
+	"github.com/mistakeknot/praude/internal/runs"
 )
 
 func TestRunCommandUnknownAgent(t *testing.T) {
@@ -86,7 +86,7 @@ args = []
 	}
 }
 
-func TestRunCommandUsesSubagentForClaude(t *testing.T) {
+func TestRunCommandUsesSubagentFromProfile(t *testing.T) {
 	root := t.TempDir()
 	if err := os.MkdirAll(filepath.Join(root, ".praude"), 0o755); err != nil {
 		t.Fatal(err)
@@ -96,6 +96,7 @@ func TestRunCommandUsesSubagentForClaude(t *testing.T) {
 [agents.claude]
 command = "claude"
 args = []
+subagent = true
 `
 	if err := os.WriteFile(filepath.Join(root, ".praude", "config.toml"), []byte(cfg), 0o644); err != nil {
 		t.Fatal(err)
@@ -106,20 +107,16 @@ args = []
 	}
 	calledSub := false
 	calledAgent := false
-	oldSub := launchSubagent
-	oldAgent := launchAgent
-	launchSubagent = func(p agents.Profile, briefPath string) error {
-		calledSub = true
-		return nil
-	}
-	launchAgent = func(p agents.Profile, briefPath string) error {
-		calledAgent = true
-		return nil
-	}
-	defer func() {
-		launchSubagent = oldSub
-		launchAgent = oldAgent
-	}()
+	oldLaunch := launchRun
+	launchRun = func(root string, req runs.Request) (runs.Run, error) {
+		if req.Profile.Subagent {
+			calledSub = true
+		} else {
+			calledAgent = true
+		}
+		return runs.Run{ID: "run-1"}, nil
+	}
+	defer func() { launchRun = oldLaunch }()
 	cwd, err := os.Getwd()
 	if err != nil {
 		t.Fatal(err)
@@ -0,0 +1,265 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/runs"
+	"github.com/spf13/cobra"
+)
+
+func RunsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "runs",
+		Short: "Inspect and control agent runs",
+	}
+	cmd.AddCommand(runsListCmd())
+	cmd.AddCommand(runsShowCmd())
+	cmd.AddCommand(runsKillCmd())
+	cmd.AddCommand(runsWaitCmd())
+	cmd.AddCommand(runsLogsCmd())
+	cmd.AddCommand(runsSuperviseCmd())
+	return cmd
+}
+
+func runsListCmd() *cobra.Command {
+	var active bool
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List agent runs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			list := runs.List
+			if active {
+				list = runs.Active
+			}
+			all, err := list(root)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if len(all) == 0 {
+				fmt.Fprintln(out, "No runs.")
+				return nil
+			}
+			now := time.Now()
+			for _, run := range all {
+				fmt.Fprintf(out, "%s\t%s\t%s\t%s\t%s\t%s\n", run.ID, run.Kind, valueOr(run.SpecID, "-"), run.Agent, runStatus(run), run.Duration(now))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&active, "active", false, "Only list pending or running runs")
+	return cmd
+}
+
+func runsShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <run>",
+		Short: "Show a run record",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			run, err := runs.Get(root, args[0])
+			if err != nil {
+				return err
+			}
+			printRun(cmd.OutOrStdout(), run)
+			return nil
+		},
+	}
+}
+
+func runsKillCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "kill <run>",
+		Short: "Stop a running agent",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			run, err := runs.Kill(root, args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Killed %s\n", run.ID)
+			return nil
+		},
+	}
+}
+
+func runsWaitCmd() *cobra.Command {
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "wait <run>",
+		Short: "Wait for a run to finish",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			run, err := runs.Wait(root, args[0], waitPoll, timeout)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", run.ID, runStatus(run))
+			printIngest(cmd.OutOrStdout(), run)
+			if run.Status != runs.StatusSucceeded {
+				return fmt.Errorf("run %s %s", run.ID, runStatus(run))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Give up after this long (0 waits forever)")
+	return cmd
+}
+
+func runsLogsCmd() *cobra.Command {
+	var follow bool
+	var tail int
+	cmd := &cobra.Command{
+		Use:   "logs <run>",
+		Short: "Print the output captured for a run",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if follow {
+				return runs.Follow(root, args[0], out, 500*time.Millisecond)
+			}
+			run, err := runs.Get(root, args[0])
+			if err != nil {
+				return err
+			}
+			if tail > 0 {
+				lines, err := runs.Tail(run, tail)
+				if err != nil {
+					return err
+				}
+				for _, line := range lines {
+					fmt.Fprintln(out, line)
+				}
+				return nil
+			}
+			files := runs.LogFiles(run)
+			if len(files) == 0 {
+				fmt.Fprintln(out, "No output captured.")
+				return nil
+			}
+			for _, path := range files {
+				raw, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				if _, err := out.Write(raw); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep printing output until the run finishes")
+	cmd.Flags().IntVar(&tail, "tail", 0, "Only print the last N lines")
+	return cmd
+}
+
+func runsSuperviseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:    "supervise <run>",
+		Short:  "Run an agent and record its result",
+		Args:   cobra.ExactArgs(1),
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			return runs.Supervise(root, args[0])
+		},
+	}
+}
+
+func runStatus(run runs.Run) string {
+	status := string(run.Status)
+	if run.ExitCode != nil && *run.ExitCode != 0 {
+		status = fmt.Sprintf("%s (exit %d)", run.Status, *run.ExitCode)
+	}
+	if run.Attempt > 1 {
+		status += fmt.Sprintf(" [attempt %d of %d]", run.Attempt, run.MaxRetries+1)
+	}
+	return status
+}
+
+func printRun(out io.Writer, run runs.Run) {
+	fmt.Fprintf(out, "Run: %s\n", run.ID)
+	fmt.Fprintf(out, "Kind: %s\n", run.Kind)
+	fmt.Fprintf(out, "Spec: %s\n", valueOr(run.SpecID, "-"))
+	fmt.Fprintf(out, "Agent: %s\n", run.Agent)
+	fmt.Fprintf(out, "Status: %s\n", runStatus(run))
+	fmt.Fprintf(out, "Command: %s\n", strings.Join(run.Command, " "))
+	fmt.Fprintf(out, "Brief: %s\n", run.Brief)
+	fmt.Fprintf(out, "Target: %s\n", valueOr(run.Target, "-"))
+	fmt.Fprintf(out, "Log: %s\n", valueOr(run.Log, "-"))
+	if run.Sandbox != "" {
+		fmt.Fprintf(out, "Sandbox: %s\n", run.Sandbox)
+	}
+	fmt.Fprintf(out, "PID: %d\n", run.PID)
+	fmt.Fprintf(out, "Started: %s\n", run.StartedAt)
+	fmt.Fprintf(out, "Ended: %s\n", valueOr(run.EndedAt, "-"))
+	if run.Error != "" {
+		fmt.Fprintf(out, "Error: %s\n", run.Error)
+	}
+	if run.Ingest != nil {
+		fmt.Fprintf(out, "Ingest: %s\n", run.Ingest.Message())
+	}
+	printDiscarded(out, run)
+}
+
+func printDiscarded(out io.Writer, run runs.Run) {
+	if len(run.Discarded) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "Discarded %d change(s) made outside the artifact:\n", len(run.Discarded))
+	for _, change := range run.Discarded {
+		fmt.Fprintf(out, "  %s\n", change)
+	}
+}
+
+func printIngest(out io.Writer, run runs.Run) {
+	printDiscarded(out, run)
+	if run.Ingest == nil {
+		return
+	}
+	fmt.Fprintln(out, run.Ingest.Message())
+	for _, issue := range run.Ingest.Errors {
+		fmt.Fprintf(out, "  error: %s\n", issue)
+	}
+	for _, issue := range run.Ingest.Warnings {
+		fmt.Fprintf(out, "  warning: %s\n", issue)
+	}
+	if !run.Ingest.Ready() {
+		return
+	}
+	switch run.Kind {
+	case "suggest":
+		fmt.Fprintf(out, "Next: praude suggestions review %s\n", run.SpecID)
+	case "research":
+		fmt.Fprintf(out, "Next: praude research ingest %s\n", run.SpecID)
+	}
+}
@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/ingest"
+	"github.com/mistakeknot/praude/internal/runs"
+)
+
+func TestRunsListAndShow(t *testing.T) {
+	root := t.TempDir()
+	now := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	done, err := runs.Create(root, runs.Run{Kind: "research", SpecID: "PRD-001", Agent: "codex", Brief: "brief.md", Command: []string{"codex", "brief.md"}}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := 2
+	done.Status = runs.StatusFailed
+	done.ExitCode = &code
+	done.EndedAt = now.Add(time.Minute).Format(time.RFC3339)
+	if err := runs.Save(done); err != nil {
+		t.Fatal(err)
+	}
+	pending, err := runs.Create(root, runs.Run{Kind: "suggest", SpecID: "PRD-002", Agent: "claude"}, now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	cmd := RunsCmd()
+	cmd.SetArgs([]string{"list"})
+	buf := bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	want := "20260115-100000-codex\tresearch\tPRD-001\tcodex\tfailed (exit 2)\t1m0s"
+	if !strings.Contains(buf.String(), want) || !strings.Contains(buf.String(), pending.ID) {
+		t.Fatalf("unexpected list output %q", buf.String())
+	}
+	cmd = RunsCmd()
+	cmd.SetArgs([]string{"list", "--active"})
+	buf = bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), done.ID) || !strings.Contains(buf.String(), pending.ID) {
+		t.Fatalf("expected only active runs, got %q", buf.String())
+	}
+	cmd = RunsCmd()
+	cmd.SetArgs([]string{"show", "20260115-10"})
+	buf = bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Command: codex brief.md") || !strings.Contains(buf.String(), "Status: failed (exit 2)") {
+		t.Fatalf("unexpected show output %q", buf.String())
+	}
+	cmd = RunsCmd()
+	cmd.SetArgs([]string{"wait", done.ID})
+	cmd.SetOut(bytes.NewBuffer(nil))
+	if err := cmd.Execute(); err == nil {
+		t.Fatalf("expected wait to report failed run")
+	}
+}
+
+func TestRunsLogsPrintsCapturedOutput(t *testing.T) {
+	root := t.TempDir()
+	run, err := runs.Create(root, runs.Run{Kind: "research", Agent: "codex"}, time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := 0
+	run.Status = runs.StatusSucceeded
+	run.ExitCode = &code
+	if err := runs.Save(run); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(run.Log+".1", []byte("first\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(run.Log, []byte("second\nthird\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"logs", run.ID}, "first\nsecond\nthird\n"},
+		{[]string{"logs", run.ID, "--tail", "1"}, "third\n"},
+		{[]string{"logs", run.ID, "--follow"}, "first\nsecond\nthird\n"},
+	} {
+		cmd := RunsCmd()
+		cmd.SetArgs(tc.args)
+		buf := bytes.NewBuffer(nil)
+		cmd.SetOut(buf)
+		if err := cmd.Execute(); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tc.want {
+			t.Fatalf("%v: unexpected output %q", tc.args, buf.String())
+		}
+	}
+}
+
+func TestRunsWaitReportsIngest(t *testing.T) {
+	root := t.TempDir()
+	run, err := runs.Create(root, runs.Run{Kind: "suggest", SpecID: "PRD-001", Agent: "codex"}, time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := 0
+	run.Status = runs.StatusSucceeded
+	run.ExitCode = &code
+	run.Ingest = &ingest.Report{Kind: "suggest", SpecID: "PRD-001", Artifact: "PRD-001-x.md", Status: ingest.StatusReady, Summary: "1 suggested change", Warnings: []string{"missing cujs"}}
+	if err := runs.Save(run); err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	cmd := RunsCmd()
+	cmd.SetArgs([]string{"wait", run.ID})
+	buf := bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"Suggestions for PRD-001 ready for review: 1 suggested change (validation: 0 errors, 1 warning)",
+		"  warning: missing cujs",
+		"Next: praude suggestions review PRD-001",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("expected %q in %q", want, buf.String())
+		}
+	}
+}
@@ -52,6 +52,7 @@ func summarizeSpec(spec specs.Spec) string {
 		"CUJ: " + itoa(len(spec.CriticalUserJourneys)),
 		"Market: " + itoa(len(spec.MarketResearch)),
 		"Competitive: " + itoa(len(spec.CompetitiveLandscape)),
+		"OSS: " + itoa(len(spec.OSSLandscape)),
 	}
 	return strings.Join(lines, "\n")
 }
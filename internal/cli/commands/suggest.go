@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"github.com/mistakeknot/praude/internal/brief"
 	"github.com/mistakeknot/praude/internal/config"
 	"github.com/mistakeknot/praude/internal/project"
+	"github.com/mistakeknot/praude/internal/runs"
 	"github.com/mistakeknot/praude/internal/specs"
 	"github.com/mistakeknot/praude/internal/suggestions"
 	"github.com/spf13/cobra"
@@ -18,6 +20,8 @@ import (
 
 func SuggestCmd() *cobra.Command {
 	var agent string
+	var agentNames []string
+	var wait bool
 	cmd := &cobra.Command{
 		Use:   "suggest <id>",
 		Short: "Create a suggestions artifact for a PRD",
@@ -31,7 +35,7 @@ func SuggestCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			profile, err := agents.Resolve(agentProfiles(cfg), agent)
+			choices, err := resolveAgents(cmd, cfg, agent, agentNames)
 			if err != nil {
 				return err
 			}
@@ -41,87 +45,113 @@ func SuggestCmd() *cobra.Command {
 			if err := os.MkdirAll(suggDir, 0o755); err != nil {
 				return err
 			}
-			suggPath, err := suggestions.Create(suggDir, id, now)
+			specPath, err := resolveSpecPath(project.SpecsDir(root), id)
 			if err != nil {
 				return err
 			}
-			briefPath, err := writeSuggestionBrief(root, id, suggPath, now)
+			spec, err := specs.LoadSpec(specPath)
 			if err != nil {
 				return err
 			}
-			fmt.Fprintln(cmd.OutOrStdout(), filepath.Base(suggPath))
-			launcher := launchAgent
-			if isClaudeProfile(agent, profile) {
-				launcher = launchSubagent
+			out := cmd.OutOrStdout()
+			repo := brief.NewRepo(root)
+			var started []runs.Run
+			for _, choice := range choices {
+				suggPath, err := suggestions.CreateFromSpec(suggDir, spec, choice.Name, now)
+				if err != nil {
+					return err
+				}
+				briefPath, err := brief.WriteSuggest(repo, id, suggPath, choice.Profile.ScanBytes(), now)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(out, filepath.Base(suggPath))
+				if run, ok := startRun(out, root, runs.Request{Kind: "suggest", SpecID: id, Agent: choice.Name, Profile: choice.Profile, Brief: briefPath, Target: suggPath}); ok {
+					started = append(started, run)
+				}
 			}
-			if err := launcher(profile, briefPath); err != nil {
-				fmt.Fprintf(cmd.OutOrStdout(), "agent not found; brief at %s\n", briefPath)
-				return nil
+			if len(choices) > 1 {
+				fmt.Fprintf(out, "Compare with: praude suggestions compare %s\n", id)
+			}
+			if wait {
+				return waitForRuns(out, root, started)
 			}
 			return nil
 		},
 	}
 	cmd.Flags().StringVar(&agent, "agent", "codex", "Agent profile to use")
+	cmd.Flags().StringSliceVar(&agentNames, "agents", nil, "Fan out to several agent profiles, each into its own artifact (e.g. codex,claude)")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait for the agent and report the ingested suggestions")
 	return cmd
 }
 
-var launchAgent = agents.Launch
-var launchSubagent = agents.LaunchSubagent
+var (
+	launchRun = runs.Launch
+	waitPoll  = 500 * time.Millisecond
+)
 
-func isClaudeProfile(name string, profile agents.Profile) bool {
-	if strings.EqualFold(name, "claude") {
-		return true
+func startRun(out io.Writer, root string, req runs.Request) (runs.Run, bool) {
+	run, err := launchRun(root, req)
+	if err != nil {
+		fmt.Fprintf(out, "%v; brief at %s\n", err, req.Brief)
+		return run, false
 	}
-	return strings.Contains(strings.ToLower(profile.Command), "claude")
+	if run.Interactive {
+		fmt.Fprintf(out, "Run %s %s\n", run.ID, runStatus(run))
+		printIngest(out, run)
+		return run, false
+	}
+	fmt.Fprintf(out, "Started run %s\n", run.ID)
+	return run, true
 }
 
-func agentProfiles(cfg config.Config) map[string]agents.Profile {
-	out := make(map[string]agents.Profile)
-	for name, profile := range cfg.Agents {
-		out[name] = agents.Profile{Command: profile.Command, Args: profile.Args}
-	}
-	return out
+type agentChoice struct {
+	Name    string
+	Profile agents.Profile
 }
 
-func writeSuggestionBrief(root, id, suggPath string, now time.Time) (string, error) {
-	briefsDir := project.BriefsDir(root)
-	if err := os.MkdirAll(briefsDir, 0o755); err != nil {
-		return "", err
+func resolveAgents(cmd *cobra.Command, cfg config.Config, agent string, names []string) ([]agentChoice, error) {
+	if len(names) == 0 {
+		names = []string{agent}
+	} else if cmd.Flags().Changed("agent") {
+		return nil, fmt.Errorf("use either --agent or --agents")
 	}
-	stamp := now.UTC().Format("20060102-150405")
-	briefPath := filepath.Join(briefsDir, id+"-"+stamp+".md")
-	specPath := filepath.Join(project.SpecsDir(root), id+".yaml")
-	spec, err := specs.LoadSpec(specPath)
-	if err != nil {
-		return "", err
+	profiles := cfg.AgentProfiles()
+	seen := make(map[string]bool)
+	var out []agentChoice
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		profile, err := agents.Resolve(profiles, name)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, agentChoice{Name: name, Profile: profile})
 	}
-	content := buildSuggestionBrief(spec, suggPath)
-	if err := os.WriteFile(briefPath, []byte(content), 0o644); err != nil {
-		return "", err
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no agents given")
 	}
-	return briefPath, nil
+	return out, nil
 }
 
-func buildSuggestionBrief(spec specs.Spec, suggPath string) string {
-	acceptance := []string{}
-	for _, item := range spec.Acceptance {
-		if strings.TrimSpace(item.Description) != "" {
-			acceptance = append(acceptance, item.Description)
+func waitForRuns(out io.Writer, root string, started []runs.Run) error {
+	for _, run := range started {
+		if err := waitForRun(out, root, run); err != nil {
+			return err
 		}
 	}
-	base := brief.Compose(brief.Input{
-		ID:           spec.ID,
-		Title:        spec.Title,
-		Summary:      spec.Summary,
-		Requirements: spec.Requirements,
-		Acceptance:   acceptance,
-		ResearchFiles: spec.Research,
-	})
-	instructions := `\n\nInstructions:
-- Create per-section suggestions for Summary, Requirements, CUJs, Market Research, Competitive Landscape.
-- Use evidence refs for all research claims.
-- Write results into the suggestions template at:
-  ` + suggPath + `
-`
-	return base + instructions
+	return nil
+}
+
+func waitForRun(out io.Writer, root string, run runs.Run) error {
+	run, err := runs.Wait(root, run.ID, waitPoll, 0)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s %s\n", run.ID, runStatus(run))
+	printIngest(out, run)
+	return nil
 }
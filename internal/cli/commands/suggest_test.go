@@ -7,7 +7,7 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/mistakeknot/praude/internal/agents"
+	"github.com/mistakeknot/praude/internal/runs"
 )
 
 func TestSuggestCommandCreatesSuggestion(t *testing.T) {
@@ -87,7 +87,7 @@ args = []
 	}
 }
 
-func TestSuggestCommandUsesSubagentForClaude(t *testing.T) {
+func TestSuggestCommandUsesSubagentFromProfile(t *testing.T) {
 	root := t.TempDir()
 	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
 		t.Fatal(err)
@@ -103,6 +103,7 @@ func TestSuggestCommandUsesSubagentForClaude(t *testing.T) {
 [agents.claude]
 command = "claude"
 args = []
+subagent = true
 `
 	if err := os.WriteFile(filepath.Join(root, ".praude", "config.toml"), []byte(cfg), 0o644); err != nil {
 		t.Fatal(err)
@@ -121,20 +122,16 @@ acceptance_criteria:
 	}
 	calledSub := false
 	calledAgent := false
-	oldSub := launchSubagent
-	oldAgent := launchAgent
-	launchSubagent = func(p agents.Profile, briefPath string) error {
-		calledSub = true
-		return nil
-	}
-	launchAgent = func(p agents.Profile, briefPath string) error {
-		calledAgent = true
-		return nil
-	}
-	defer func() {
-		launchSubagent = oldSub
-		launchAgent = oldAgent
-	}()
+	oldLaunch := launchRun
+	launchRun = func(root string, req runs.Request) (runs.Run, error) {
+		if req.Profile.Subagent {
+			calledSub = true
+		} else {
+			calledAgent = true
+		}
+		return runs.Run{ID: "run-1"}, nil
+	}
+	defer func() { launchRun = oldLaunch }()
 	cwd, err := os.Getwd()
 	if err != nil {
 		t.Fatal(err)
@@ -158,3 +155,94 @@ acceptance_criteria:
 		t.Fatalf("expected main launch to be skipped")
 	}
 }
+
+func TestSuggestCommandFansOutToAgents(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := `validation_mode = "soft"
+
+[agents.codex]
+command = "codex"
+args = []
+
+[agents.claude]
+command = "claude"
+args = []
+scan_budget = -1
+`
+	if err := os.WriteFile(filepath.Join(root, ".praude", "config.toml"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	spec := `id: "PRD-001"
+title: "Alpha"
+summary: "Summary"
+requirements:
+  - "REQ-001: R"
+`
+	if err := os.WriteFile(filepath.Join(root, ".praude", "specs", "PRD-001.yaml"), []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte("# Alpha\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var reqs []runs.Request
+	oldLaunch := launchRun
+	launchRun = func(root string, req runs.Request) (runs.Run, error) {
+		reqs = append(reqs, req)
+		return runs.Run{ID: "run-" + req.Agent}, nil
+	}
+	defer func() { launchRun = oldLaunch }()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	cmd := SuggestCmd()
+	cmd.SetArgs([]string{"PRD-001", "--agents", "codex,claude"})
+	buf := bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if len(reqs) != 2 || reqs[0].Agent != "codex" || reqs[1].Agent != "claude" {
+		t.Fatalf("expected a launch per agent, got %+v", reqs)
+	}
+	if reqs[0].Target == reqs[1].Target || reqs[0].Brief == reqs[1].Brief {
+		t.Fatalf("expected separate artifacts and briefs, got %+v", reqs)
+	}
+	for _, req := range reqs {
+		raw, err := os.ReadFile(req.Target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(raw), `agent: "`+req.Agent+`"`) {
+			t.Fatalf("expected %s to record agent %s", req.Target, req.Agent)
+		}
+	}
+	for _, req := range reqs {
+		raw, err := os.ReadFile(req.Brief)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := strings.Contains(string(raw), "--- README.md (readme)"), req.Agent == "codex"; got != want {
+			t.Fatalf("expected repo context in %s brief: %v, got %q", req.Agent, want, raw)
+		}
+	}
+	if !strings.Contains(buf.String(), "Compare with: praude suggestions compare PRD-001") {
+		t.Fatalf("expected compare hint, got %q", buf.String())
+	}
+
+	cmd = SuggestCmd()
+	cmd.SetArgs([]string{"PRD-001", "--agent", "codex", "--agents", "claude"})
+	cmd.SetOut(bytes.NewBuffer(nil))
+	cmd.SetErr(bytes.NewBuffer(nil))
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "either --agent or --agents") {
+		t.Fatalf("expected conflicting flags error, got %v", err)
+	}
+}
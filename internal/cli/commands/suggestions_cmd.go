@@ -3,12 +3,19 @@ package commands
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/mistakeknot/praude/internal/agents"
+	"github.com/mistakeknot/praude/internal/brief"
+	"github.com/mistakeknot/praude/internal/config"
 	"github.com/mistakeknot/praude/internal/git"
 	"github.com/mistakeknot/praude/internal/project"
+	"github.com/mistakeknot/praude/internal/runs"
+	"github.com/mistakeknot/praude/internal/specs"
 	"github.com/mistakeknot/praude/internal/suggestions"
 	"github.com/spf13/cobra"
 )
@@ -18,15 +25,90 @@ func SuggestionsCmd() *cobra.Command {
 		Use:   "suggestions",
 		Short: "Review or apply suggestion files",
 	}
+	cmd.AddCommand(suggestionsListCmd())
 	cmd.AddCommand(suggestionsReviewCmd())
 	cmd.AddCommand(suggestionsApplyCmd())
+	cmd.AddCommand(suggestionsUndoCmd())
+	cmd.AddCommand(suggestionsRejectCmd())
+	cmd.AddCommand(suggestionsCompareCmd())
 	return cmd
 }
 
-func suggestionsReviewCmd() *cobra.Command {
+func suggestionsCompareCmd() *cobra.Command {
+	var all bool
+	var files []string
+	cmd := &cobra.Command{
+		Use:   "compare <id>",
+		Short: "Line up suggestions from several agents per section",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			id := args[0]
+			specPath, err := resolveSpecPath(project.SpecsDir(root), id)
+			if err != nil {
+				return err
+			}
+			current, err := specs.LoadSpec(specPath)
+			if err != nil {
+				return err
+			}
+			var compared []suggestions.File
+			if len(files) > 0 {
+				for _, file := range files {
+					path, err := resolveSuggestionPath(root, file)
+					if err != nil {
+						return err
+					}
+					compared = append(compared, suggestions.Open(path))
+				}
+			} else {
+				listed, err := suggestions.List(project.SuggestionsDir(root), id)
+				if err != nil {
+					return err
+				}
+				for _, file := range listed {
+					if file.Err == nil && (all || file.Status() == suggestions.StatusPending) {
+						compared = append(compared, file)
+					}
+				}
+			}
+			if len(compared) < 2 {
+				return fmt.Errorf("need at least two suggestion files to compare for %s, found %d", id, len(compared))
+			}
+			out := cmd.OutOrStdout()
+			labels := suggestions.CompareLabels(compared)
+			fmt.Fprintf(out, "Comparing %s:\n", id)
+			for _, file := range compared {
+				if file.Err != nil {
+					return fmt.Errorf("%s: %w", file.Name, file.Err)
+				}
+				fmt.Fprintf(out, "  %s\t%s\n", labels[file.Name], file.Name)
+			}
+			sections := suggestions.Compare(compared, current)
+			if len(sections) == 0 {
+				fmt.Fprintln(out, "No suggested changes.")
+				return nil
+			}
+			fmt.Fprintln(out)
+			for _, line := range suggestions.RenderComparison(sections) {
+				fmt.Fprintln(out, line)
+			}
+			fmt.Fprintf(out, "\nCherry-pick with: praude suggestions apply %s --file <file>\n", id)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&all, "all", false, "Include reviewed suggestion files")
+	cmd.Flags().StringArrayVar(&files, "file", nil, "Compare specific suggestion files (repeatable)")
+	return cmd
+}
+
+func suggestionsListCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "review <id>",
-		Short: "Review latest suggestions for a PRD",
+		Use:   "list <id>",
+		Short: "List suggestion files for a PRD",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			root, err := os.Getwd()
@@ -34,24 +116,123 @@ func suggestionsReviewCmd() *cobra.Command {
 				return err
 			}
 			id := args[0]
-			sugg, path, err := suggestions.LoadLatest(project.SuggestionsDir(root), id)
+			files, err := suggestions.List(project.SuggestionsDir(root), id)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if len(files) == 0 {
+				fmt.Fprintf(out, "No suggestions for %s\n", id)
+				return nil
+			}
+			for _, file := range files {
+				fmt.Fprintf(out, "%s\t%s\t%s\t%s\n", file.Name, valueOr(file.Suggestion.Agent, "-"), formatStamp(file.CreatedAt), file.Status())
+			}
+			return nil
+		},
+	}
+}
+
+func suggestionsReviewCmd() *cobra.Command {
+	var all bool
+	var showDiff bool
+	var file string
+	cmd := &cobra.Command{
+		Use:   "review <id>",
+		Short: "Review pending suggestions for a PRD",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := os.Getwd()
 			if err != nil {
 				return err
 			}
+			id := args[0]
+			var files []suggestions.File
+			if file != "" {
+				path, err := resolveSuggestionPath(root, file)
+				if err != nil {
+					return err
+				}
+				files = []suggestions.File{suggestions.Open(path)}
+				all = true
+			} else if files, err = suggestions.List(project.SuggestionsDir(root), id); err != nil {
+				return err
+			}
+			var current specs.Spec
+			if showDiff {
+				specPath, err := resolveSpecPath(project.SpecsDir(root), id)
+				if err != nil {
+					return err
+				}
+				if current, err = specs.LoadSpec(specPath); err != nil {
+					return err
+				}
+			}
 			out := cmd.OutOrStdout()
-			fmt.Fprintf(out, "File: %s\n", filepath.Base(path))
-			fmt.Fprintf(out, "Summary: %s\n", yesNo(sugg.Summary != ""))
-			fmt.Fprintf(out, "Requirements: %d\n", len(sugg.Requirements))
-			fmt.Fprintf(out, "CUJ: %d\n", len(sugg.CriticalUserJourneys))
-			fmt.Fprintf(out, "Market: %d\n", len(sugg.MarketResearch))
-			fmt.Fprintf(out, "Competitive: %d\n", len(sugg.CompetitiveLandscape))
+			shown, failed := 0, 0
+			for _, file := range files {
+				if file.Err != nil {
+					if shown > 0 {
+						fmt.Fprintln(out)
+					}
+					shown++
+					failed++
+					fmt.Fprintf(out, "File: %s\n", file.Name)
+					fmt.Fprintf(out, "Error: %v\n", file.Err)
+					continue
+				}
+				status := file.Status()
+				if !all && status != suggestions.StatusPending {
+					continue
+				}
+				if shown > 0 {
+					fmt.Fprintln(out)
+				}
+				shown++
+				sugg := file.Suggestion
+				fmt.Fprintf(out, "File: %s\n", file.Name)
+				fmt.Fprintf(out, "Status: %s\n", status)
+				if sugg.Agent != "" {
+					fmt.Fprintf(out, "Agent: %s\n", sugg.Agent)
+				}
+				if sugg.Parent != "" {
+					fmt.Fprintf(out, "Follows: %s\n", sugg.Parent)
+				}
+				for _, key := range suggestions.SectionKeys() {
+					fmt.Fprintf(out, "%s: %s\n", suggestions.SectionLabel(key), suggestions.SectionCount(sugg, key))
+				}
+				if review := reviewedBy(sugg); review != "" {
+					fmt.Fprintf(out, "Reviewed: %s\n", review)
+				}
+				if showDiff {
+					if err := printSuggestionDiff(out, current, sugg, config.LoadValidationOptions(root)); err != nil {
+						return err
+					}
+				}
+			}
+			if shown == 0 {
+				if len(files) == 0 {
+					return fmt.Errorf("no suggestions for %s", id)
+				}
+				fmt.Fprintf(out, "No pending suggestions for %s\n", id)
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d suggestion files could not be parsed", failed)
+			}
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&all, "all", false, "Include reviewed suggestion files")
+	cmd.Flags().BoolVar(&showDiff, "diff", false, "Show the spec diff after applying all suggestions")
+	cmd.Flags().StringVar(&file, "file", "", "Review a specific suggestion file")
+	return cmd
 }
 
 func suggestionsApplyCmd() *cobra.Command {
 	var all bool
+	var reviewer string
+	var onConflict string
+	var file string
 	cmd := &cobra.Command{
 		Use:   "apply <id>",
 		Short: "Apply suggestions to a PRD",
@@ -61,94 +242,314 @@ func suggestionsApplyCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if err := validateConflictMode(onConflict); err != nil {
+				return err
+			}
 			id := args[0]
-			sugg, _, err := suggestions.LoadLatest(project.SuggestionsDir(root), id)
+			sugg, suggPath, err := loadSuggestionFile(root, id, file)
+			if err != nil {
+				return err
+			}
+			specPath, err := resolveSpecPath(project.SpecsDir(root), id)
+			if err != nil {
+				return err
+			}
+			current, err := specs.LoadSpec(specPath)
+			if err != nil {
+				return err
+			}
+			conflicts, err := suggestions.CheckConflicts(project.SuggestionsDir(root), sugg, current)
+			if err != nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "WARN: cannot check for conflicts: %v\n", err)
+			}
+			conflicted := make(map[string]bool)
+			for _, item := range conflicts {
+				conflicted[item.Key()] = true
+			}
+			if len(conflicts) > 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "Conflicts: %d items conflict with the current spec\n", len(conflicts))
+				for _, item := range conflicts {
+					fmt.Fprintf(cmd.OutOrStdout(), "  %s: %s (%s)\n", suggestions.SectionTitle(item.Section), item.Label, item.Reason)
+				}
+				if onConflict == conflictRefuse {
+					return fmt.Errorf("refusing to apply %d conflicting suggestions (use --on-conflict=ask|ours|theirs)", len(conflicts))
+				}
+			}
+			offered := suggestions.Items(sugg, current)
+			accepted, err := selectSuggestions(cmd, offered, all, conflicted, onConflict)
 			if err != nil {
 				return err
 			}
-			selected, err := selectSuggestions(cmd, sugg, all)
+			keep := func(item suggestions.Item) bool { return accepted[item.Key()] }
+			previous, err := os.ReadFile(specPath)
 			if err != nil {
 				return err
 			}
-			specPath := filepath.Join(project.SpecsDir(root), id+".yaml")
-			if err := suggestions.Apply(specPath, selected); err != nil {
+			delta, err := suggestions.Apply(specPath, suggestions.Select(sugg, keep), config.LoadValidationOptions(root))
+			printValidationDelta(cmd.OutOrStdout(), delta)
+			if err != nil {
+				return err
+			}
+			if reviewer == "" {
+				reviewer = suggestions.Reviewer(root)
+			}
+			now := time.Now()
+			if err := suggestions.RecordReview(suggPath, offered, keep, reviewer, now); err != nil {
+				return err
+			}
+			histPath, err := suggestions.RecordApply(project.SuggestionsDir(root), id, specPath, suggPath, previous, suggestions.AppliedSections(offered, keep), reviewer, now)
+			if err != nil {
 				return err
 			}
 			if err := git.EnsureRepo(root); err == nil {
-				_ = git.CommitFiles(root, []string{specPath}, "chore(praude): apply suggestions "+id)
+				_ = git.CommitFiles(root, []string{specPath, suggPath, histPath}, "chore(praude): apply suggestions "+id)
 			}
 			fmt.Fprintf(cmd.OutOrStdout(), "Applied suggestions to %s\n", id)
 			return nil
 		},
 	}
 	cmd.Flags().BoolVar(&all, "all", false, "Apply all suggestions without prompts")
+	cmd.Flags().StringVar(&reviewer, "reviewer", "", "Reviewer recorded in the suggestion file")
+	cmd.Flags().StringVar(&onConflict, "on-conflict", conflictAsk, "Conflict handling (ask|refuse|ours|theirs)")
+	cmd.Flags().StringVar(&file, "file", "", "Apply a specific suggestion file instead of the latest")
+	return cmd
+}
+
+func suggestionsUndoCmd() *cobra.Command {
+	var force bool
+	cmd := &cobra.Command{
+		Use:   "undo <id>",
+		Short: "Restore a PRD to its state before the last applied suggestions",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			id := args[0]
+			specPath, err := resolveSpecPath(project.SpecsDir(root), id)
+			if err != nil {
+				return err
+			}
+			suggDir := project.SuggestionsDir(root)
+			rec, err := suggestions.Undo(suggDir, id, specPath, force, suggestions.Reviewer(root), time.Now())
+			if err != nil {
+				return err
+			}
+			if err := git.EnsureRepo(root); err == nil {
+				_ = git.CommitFiles(root, []string{specPath, filepath.Join(suggDir, rec.Suggestion), rec.Path}, "chore(praude): undo suggestions "+id)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Reverted %s applied at %s (%s)\n", rec.Suggestion, rec.AppliedAt, strings.Join(sectionLabels(rec.Sections), ", "))
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&force, "force", false, "Restore even if the spec changed after the suggestions were applied")
 	return cmd
 }
 
-func selectSuggestions(cmd *cobra.Command, sugg suggestions.Suggestion, all bool) (suggestions.Suggestion, error) {
-	if all {
-		return suggestions.Suggestion{
-			Summary:              sugg.Summary,
-			Requirements:         sugg.Requirements,
-			CriticalUserJourneys: sugg.CriticalUserJourneys,
-			MarketResearch:       sugg.MarketResearch,
-			CompetitiveLandscape: sugg.CompetitiveLandscape,
-		}, nil
+func suggestionsRejectCmd() *cobra.Command {
+	var file string
+	var agent string
+	var reasons []string
+	cmd := &cobra.Command{
+		Use:   "reject <id>",
+		Short: "Reject suggestions and re-prompt the agent with feedback",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			id := args[0]
+			feedback, err := parseReasons(reasons)
+			if err != nil {
+				return err
+			}
+			sugg, suggPath, err := loadSuggestionFile(root, id, file)
+			if err != nil {
+				return err
+			}
+			specPath, err := resolveSpecPath(project.SpecsDir(root), id)
+			if err != nil {
+				return err
+			}
+			current, err := specs.LoadSpec(specPath)
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			var profile agents.Profile
+			var nextPath, briefPath string
+			if len(feedback) > 0 {
+				cfg, err := config.LoadFromRoot(root)
+				if err != nil {
+					return err
+				}
+				agent = valueOr(agent, valueOr(sugg.Agent, "codex"))
+				if profile, err = agents.Resolve(cfg.AgentProfiles(), agent); err != nil {
+					return err
+				}
+				if nextPath, err = suggestions.CreateFollowUp(project.SuggestionsDir(root), current, agent, filepath.Base(suggPath), now); err != nil {
+					return err
+				}
+				if briefPath, err = brief.WriteFeedback(brief.NewRepo(root), id, suggPath, nextPath, feedback, profile.ScanBytes(), now); err != nil {
+					_ = os.Remove(nextPath)
+					return err
+				}
+			}
+			if err := suggestions.RecordRejection(suggPath, suggestions.Items(sugg, current), feedback, suggestions.Reviewer(root), now); err != nil {
+				return err
+			}
+			if err := git.EnsureRepo(root); err == nil {
+				_ = git.CommitFiles(root, []string{suggPath}, "chore(praude): reject suggestions "+id)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Rejected %s\n", filepath.Base(suggPath))
+			if len(feedback) == 0 {
+				return nil
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), filepath.Base(nextPath))
+			startRun(cmd.OutOrStdout(), root, runs.Request{Kind: "suggest", SpecID: id, Agent: agent, Profile: profile, Brief: briefPath, Target: nextPath})
+			return nil
+		},
 	}
-	reader := bufio.NewReader(cmd.InOrStdin())
-	out := cmd.OutOrStdout()
-	selected := suggestions.Suggestion{}
-	if strings.TrimSpace(sugg.Summary) != "" {
-		ok, err := promptYesNo(reader, out, "Apply summary? (y/n) ")
-		if err != nil {
-			return selected, err
+	cmd.Flags().StringVar(&file, "file", "", "Reject a specific suggestion file instead of the latest")
+	cmd.Flags().StringVar(&agent, "agent", "", "Agent profile to re-prompt (defaults to the original agent)")
+	cmd.Flags().StringArrayVar(&reasons, "reason", nil, "Feedback for a section as section=reason (repeatable); re-prompts the agent")
+	return cmd
+}
+
+func parseReasons(values []string) (map[string]string, error) {
+	out := make(map[string]string)
+	for _, value := range values {
+		name, reason, ok := strings.Cut(value, "=")
+		if !ok || strings.TrimSpace(reason) == "" {
+			return nil, fmt.Errorf("invalid reason %q (want section=reason)", value)
 		}
-		if ok {
-			selected.Summary = sugg.Summary
+		key, ok := suggestions.LookupSection(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown section %q", name)
 		}
+		out[key] = strings.TrimSpace(reason)
 	}
-	if len(sugg.Requirements) > 0 {
-		ok, err := promptYesNo(reader, out, "Apply requirements? (y/n) ")
-		if err != nil {
-			return selected, err
-		}
-		if ok {
-			selected.Requirements = sugg.Requirements
-		}
+	return out, nil
+}
+
+func sectionLabels(keys []string) []string {
+	out := make([]string, 0, len(keys))
+	for _, key := range keys {
+		out = append(out, suggestions.SectionLabel(key))
 	}
-	if len(sugg.CriticalUserJourneys) > 0 {
-		ok, err := promptYesNo(reader, out, "Apply CUJs? (y/n) ")
-		if err != nil {
-			return selected, err
-		}
-		if ok {
-			selected.CriticalUserJourneys = sugg.CriticalUserJourneys
-		}
+	return out
+}
+
+func printValidationDelta(out io.Writer, delta suggestions.ValidationDelta) {
+	if len(delta.New) == 0 && len(delta.Resolved) == 0 {
+		return
 	}
-	if len(sugg.MarketResearch) > 0 {
-		ok, err := promptYesNo(reader, out, "Apply market research? (y/n) ")
-		if err != nil {
-			return selected, err
+	fmt.Fprintf(out, "Validation: %d new, %d resolved\n", len(delta.New), len(delta.Resolved))
+	for _, issue := range delta.New {
+		fmt.Fprintln(out, "  +", issue)
+	}
+	for _, issue := range delta.Resolved {
+		fmt.Fprintln(out, "  -", issue)
+	}
+}
+
+func loadSuggestionFile(root, id, file string) (suggestions.Suggestion, string, error) {
+	if file == "" {
+		return suggestions.LoadLatest(project.SuggestionsDir(root), id)
+	}
+	path, err := resolveSuggestionPath(root, file)
+	if err != nil {
+		return suggestions.Suggestion{}, "", err
+	}
+	sugg, err := suggestions.Load(path)
+	return sugg, path, err
+}
+
+func resolveSuggestionPath(root, file string) (string, error) {
+	if _, err := os.Stat(file); err == nil {
+		return file, nil
+	}
+	path := filepath.Join(project.SuggestionsDir(root), filepath.Base(file))
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("suggestion file not found: %s", file)
+	}
+	return path, nil
+}
+
+func formatStamp(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+const (
+	conflictAsk    = "ask"
+	conflictRefuse = "refuse"
+	conflictOurs   = "ours"
+	conflictTheirs = "theirs"
+)
+
+func validateConflictMode(mode string) error {
+	switch mode {
+	case conflictAsk, conflictRefuse, conflictOurs, conflictTheirs:
+		return nil
+	}
+	return fmt.Errorf("invalid conflict mode %q", mode)
+}
+
+func selectSuggestions(cmd *cobra.Command, offered []suggestions.Item, all bool, conflicted map[string]bool, onConflict string) (map[string]bool, error) {
+	accepted := make(map[string]bool)
+	reader := bufio.NewReader(cmd.InOrStdin())
+	out := cmd.OutOrStdout()
+	for _, item := range offered {
+		conflict := conflicted[item.Key()]
+		if conflict && onConflict == conflictOurs {
+			accepted[item.Key()] = false
+			continue
 		}
-		if ok {
-			selected.MarketResearch = sugg.MarketResearch
+		if all && !(conflict && onConflict == conflictAsk) {
+			accepted[item.Key()] = true
+			continue
 		}
-	}
-	if len(sugg.CompetitiveLandscape) > 0 {
-		ok, err := promptYesNo(reader, out, "Apply competitive landscape? (y/n) ")
-		if err != nil {
-			return selected, err
+		note := ""
+		if conflict {
+			note = " (conflicts with spec edits)"
 		}
-		if ok {
-			selected.CompetitiveLandscape = sugg.CompetitiveLandscape
+		prompt := fmt.Sprintf("%s: %s %s%s. Apply? (y/n) ", suggestions.SectionTitle(item.Section), item.Action, item.Label, note)
+		ok, err := promptYesNo(reader, out, prompt)
+		if err != nil {
+			return nil, err
 		}
+		accepted[item.Key()] = ok
 	}
-	return selected, nil
+	return accepted, nil
 }
 
-func yesNo(ok bool) string {
-	if ok {
-		return "yes"
+func printSuggestionDiff(out io.Writer, current specs.Spec, sugg suggestions.Suggestion, opts specs.ValidationOptions) error {
+	lines, err := suggestions.RenderDiff(current, sugg, opts)
+	fmt.Fprintln(out, "Diff:")
+	for _, line := range lines {
+		fmt.Fprintln(out, line)
+	}
+	return err
+}
+
+func reviewedBy(sugg suggestions.Suggestion) string {
+	for _, key := range suggestions.SectionKeys() {
+		review := sugg.Reviews[key]
+		if review.ReviewedBy != "" || review.ReviewedAt != "" {
+			return strings.TrimSpace(review.ReviewedBy + " " + review.ReviewedAt)
+		}
 	}
-	return "no"
+	return ""
 }
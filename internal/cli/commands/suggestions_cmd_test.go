@@ -8,6 +8,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/mistakeknot/praude/internal/config"
+	"github.com/mistakeknot/praude/internal/runs"
+	"github.com/mistakeknot/praude/internal/specs"
 	"github.com/mistakeknot/praude/internal/suggestions"
 )
 
@@ -149,3 +152,590 @@ requirements:
 		t.Fatalf("expected requirements updated")
 	}
 }
+
+func TestSuggestionsApplyPromptsPerItem(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "suggestions"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	spec := `id: "PRD-001"
+title: "Alpha"
+summary: "Old"
+requirements:
+  - "REQ-001: Keep me"
+  - "REQ-002: Old two"
+`
+	specPath := filepath.Join(root, ".praude", "specs", "PRD-001.yaml")
+	if err := os.WriteFile(specPath, []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	body := `# Suggestions for PRD-001
+
+## Requirements
+- status: pending
+- suggestion:
+  - "REQ-002: New two"
+  - "REQ-003: Brand new"
+`
+	if err := os.WriteFile(filepath.Join(root, ".praude", "suggestions", "PRD-001-20260115-000000.md"), []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	cmd := SuggestionsCmd()
+	cmd.SetArgs([]string{"apply", "PRD-001"})
+	buf := bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetIn(strings.NewReader("n\ny\n"))
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Requirements: update REQ-002: New two") {
+		t.Fatalf("expected per-item prompt, got %q", buf.String())
+	}
+	raw, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(raw)
+	if !strings.Contains(content, "REQ-001: Keep me") || !strings.Contains(content, "REQ-002: Old two") {
+		t.Fatalf("expected untouched requirements kept, got %q", content)
+	}
+	if !strings.Contains(content, "REQ-003: Brand new") {
+		t.Fatalf("expected accepted requirement added, got %q", content)
+	}
+}
+
+func TestSuggestionsReviewListsOnlyPending(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "suggestions"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	spec := `id: "PRD-001"
+title: "Alpha"
+summary: "Old"
+`
+	if err := os.WriteFile(filepath.Join(root, ".praude", "specs", "PRD-001.yaml"), []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	body := `# Suggestions for PRD-001
+
+## Summary
+- status: pending
+- suggestion: "New summary"
+`
+	for _, name := range []string{"PRD-001-20260115-000000.md", "PRD-001-20260116-000000.md"} {
+		if err := os.WriteFile(filepath.Join(root, ".praude", "suggestions", name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	apply := SuggestionsCmd()
+	apply.SetArgs([]string{"apply", "PRD-001", "--all", "--reviewer", "alice"})
+	apply.SetOut(bytes.NewBuffer(nil))
+	if err := apply.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(filepath.Join(root, ".praude", "suggestions", "PRD-001-20260116-000000.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw), "- status: accepted") || !strings.Contains(string(raw), `- reviewed_by: "alice"`) {
+		t.Fatalf("expected review recorded, got %q", string(raw))
+	}
+	cmd := SuggestionsCmd()
+	cmd.SetArgs([]string{"review", "PRD-001"})
+	buf := bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "PRD-001-20260115-000000.md") || strings.Contains(out, "PRD-001-20260116-000000.md") {
+		t.Fatalf("expected only pending file, got %q", out)
+	}
+	cmd = SuggestionsCmd()
+	cmd.SetArgs([]string{"review", "PRD-001", "--all"})
+	buf = bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Status: accepted") {
+		t.Fatalf("expected reviewed file with --all, got %q", buf.String())
+	}
+}
+
+func TestSuggestionsReviewDiffShowsPreview(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "suggestions"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	spec := `id: "PRD-001"
+title: "Alpha"
+summary: "Old"
+requirements:
+  - "REQ-001: Old"
+`
+	if err := os.WriteFile(filepath.Join(root, ".praude", "specs", "PRD-001.yaml"), []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".praude", "config.toml"), []byte(config.DefaultConfigToml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	body := `# Suggestions for PRD-001
+
+## Requirements
+- status: pending
+- suggestion:
+  - "REQ-001: New"
+`
+	if err := os.WriteFile(filepath.Join(root, ".praude", "suggestions", "PRD-001-20260115-000000.md"), []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	cmd := SuggestionsCmd()
+	cmd.SetArgs([]string{"review", "PRD-001", "--diff"})
+	buf := bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "@@ requirements @@") || !strings.Contains(out, "- 'REQ-001: Old'") || !strings.Contains(out, "+ ") {
+		t.Fatalf("expected requirements diff, got %q", out)
+	}
+	if strings.Contains(out, "@@ summary @@") {
+		t.Fatalf("expected unchanged sections omitted, got %q", out)
+	}
+	if !strings.Contains(out, "Validation after apply:") {
+		t.Fatalf("expected validation result, got %q", out)
+	}
+}
+
+func TestSuggestionsApplyHandlesConflicts(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	suggDir := filepath.Join(root, ".praude", "suggestions")
+	if err := os.MkdirAll(suggDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	base := specs.Spec{ID: "PRD-001", Title: "Alpha", Requirements: []string{"REQ-001: One", "REQ-002: Two"}}
+	suggPath, err := suggestions.CreateFromSpec(suggDir, base, "codex", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := suggestions.FillSections(suggPath, map[string]string{suggestions.SectionRequirements: "requirements:\n  - \"REQ-001: Agent one\"\n  - \"REQ-002: Agent two\""}); err != nil {
+		t.Fatal(err)
+	}
+	edited := base
+	edited.Requirements = []string{"REQ-001: One", "REQ-002: Human two"}
+	specPath := filepath.Join(root, ".praude", "specs", "PRD-001.yaml")
+	if err := specs.SaveSpec(specPath, edited); err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	cmd := SuggestionsCmd()
+	cmd.SetArgs([]string{"apply", "PRD-001", "--all", "--on-conflict", "refuse"})
+	buf := bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	if err := cmd.Execute(); err == nil {
+		t.Fatalf("expected refusal on conflict")
+	}
+	if !strings.Contains(buf.String(), "REQ-002: Agent two") {
+		t.Fatalf("expected conflicting item listed, got %q", buf.String())
+	}
+	cmd = SuggestionsCmd()
+	cmd.SetArgs([]string{"apply", "PRD-001", "--all", "--on-conflict", "ours"})
+	cmd.SetOut(bytes.NewBuffer(nil))
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	out, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "REQ-001: Agent one") || !strings.Contains(string(out), "REQ-002: Human two") {
+		t.Fatalf("expected non-conflicting applied and human edit kept, got %q", string(out))
+	}
+}
+
+func TestSuggestionsListAndApplyFile(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	suggDir := filepath.Join(root, ".praude", "suggestions")
+	if err := os.MkdirAll(suggDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	base := specs.Spec{ID: "PRD-001", Title: "Alpha", Summary: "Old"}
+	specPath := filepath.Join(root, ".praude", "specs", "PRD-001.yaml")
+	if err := specs.SaveSpec(specPath, base); err != nil {
+		t.Fatal(err)
+	}
+	for i, agent := range []string{"claude", "codex"} {
+		path, err := suggestions.CreateFromSpec(suggDir, base, agent, time.Date(2026, 1, 15, 10, i, 0, 0, time.UTC))
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body := strings.Replace(string(raw), `# summary: ""`, `summary: "From `+agent+`"`, 1)
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	cmd := SuggestionsCmd()
+	cmd.SetArgs([]string{"list", "PRD-001"})
+	buf := bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	want := "PRD-001-20260115-100000.md\tclaude\t2026-01-15T10:00:00Z\tpending"
+	if !strings.Contains(buf.String(), want) || !strings.Contains(buf.String(), "\tcodex\t") {
+		t.Fatalf("expected listing with agents, got %q", buf.String())
+	}
+	cmd = SuggestionsCmd()
+	cmd.SetArgs([]string{"apply", "PRD-001", "--all", "--file", "PRD-001-20260115-100000.md"})
+	cmd.SetOut(bytes.NewBuffer(nil))
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw), "From claude") {
+		t.Fatalf("expected earlier file applied, got %q", string(raw))
+	}
+}
+
+func TestSuggestionsApplyRefusesInvalidSpecInHardMode(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	suggDir := filepath.Join(root, ".praude", "suggestions")
+	if err := os.MkdirAll(suggDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".praude", "config.toml"), []byte("validation_mode = \"hard\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	base := specs.Spec{ID: "PRD-001", Title: "Alpha", Summary: "Old", Requirements: []string{"REQ-001: One"}}
+	specPath := filepath.Join(root, ".praude", "specs", "PRD-001.yaml")
+	if err := specs.SaveSpec(specPath, base); err != nil {
+		t.Fatal(err)
+	}
+	suggPath, err := suggestions.CreateFromSpec(suggDir, base, "codex", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(suggPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := strings.ReplaceAll(string(raw), "\n# ", "\n")
+	body = strings.Replace(body, `summary: ""`, `summary: "New"`, 1)
+	body = strings.Replace(body, `- "REQ-001"`, `- "REQ-404"`, 1)
+	if err := os.WriteFile(suggPath, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	cmd := SuggestionsCmd()
+	cmd.SetArgs([]string{"apply", "PRD-001", "--all"})
+	buf := bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	err = cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "REQ-404") {
+		t.Fatalf("expected validation refusal, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "+ cuj linked requirement not found: REQ-404") {
+		t.Fatalf("expected validation delta, got %q", buf.String())
+	}
+	after, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(after) != string(before) {
+		t.Fatalf("expected spec unchanged")
+	}
+}
+
+func TestSuggestionsUndoRestoresSpec(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	suggDir := filepath.Join(root, ".praude", "suggestions")
+	if err := os.MkdirAll(suggDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	base := specs.Spec{ID: "PRD-001", Title: "Alpha", Summary: "Old"}
+	specPath := filepath.Join(root, ".praude", "specs", "PRD-001.yaml")
+	if err := specs.SaveSpec(specPath, base); err != nil {
+		t.Fatal(err)
+	}
+	original, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	suggPath, err := suggestions.CreateFromSpec(suggDir, base, "codex", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(suggPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(suggPath, []byte(strings.Replace(string(raw), `# summary: ""`, `summary: "New"`, 1)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	cmd := SuggestionsCmd()
+	cmd.SetArgs([]string{"apply", "PRD-001", "--all", "--reviewer", "alice"})
+	cmd.SetOut(bytes.NewBuffer(nil))
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	cmd = SuggestionsCmd()
+	cmd.SetArgs([]string{"undo", "PRD-001"})
+	buf := bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Reverted PRD-001-20260115-000000.md") {
+		t.Fatalf("expected undo output, got %q", buf.String())
+	}
+	restored, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(restored) != string(original) {
+		t.Fatalf("expected original spec, got %q", restored)
+	}
+	cmd = SuggestionsCmd()
+	cmd.SetArgs([]string{"list", "PRD-001"})
+	buf = bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "\treverted") {
+		t.Fatalf("expected reverted status, got %q", buf.String())
+	}
+}
+
+func TestSuggestionsRejectWithReasonRelaunchesAgent(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	suggDir := filepath.Join(root, ".praude", "suggestions")
+	if err := os.MkdirAll(suggDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".praude", "config.toml"), []byte(config.DefaultConfigToml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	base := specs.Spec{ID: "PRD-001", Title: "Alpha", Summary: "Old"}
+	if err := specs.SaveSpec(filepath.Join(root, ".praude", "specs", "PRD-001.yaml"), base); err != nil {
+		t.Fatal(err)
+	}
+	suggPath, err := suggestions.CreateFromSpec(suggDir, base, "codex", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(suggPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(suggPath, []byte(strings.Replace(string(raw), `# summary: ""`, `summary: "Vague"`, 1)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var launched string
+	oldLaunch := launchRun
+	launchRun = func(root string, req runs.Request) (runs.Run, error) {
+		launched = req.Brief
+		return runs.Run{ID: "run-1"}, nil
+	}
+	defer func() { launchRun = oldLaunch }()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	cmd := SuggestionsCmd()
+	cmd.SetArgs([]string{"reject", "PRD-001", "--agent", "codx", "--reason", "summary=too vague"})
+	cmd.SetOut(bytes.NewBuffer(nil))
+	cmd.SetErr(bytes.NewBuffer(nil))
+	if err := cmd.Execute(); err == nil {
+		t.Fatalf("expected unknown agent error")
+	}
+	if status := suggestions.Open(suggPath).Status(); status != suggestions.StatusPending {
+		t.Fatalf("expected file left pending after a failed re-prompt, got %s", status)
+	}
+	if files, _ := suggestions.List(suggDir, "PRD-001"); len(files) != 1 || launched != "" {
+		t.Fatalf("expected no follow-up, got %d files", len(files))
+	}
+	cmd = SuggestionsCmd()
+	cmd.SetArgs([]string{"reject", "PRD-001", "--reason", "summary=too vague"})
+	buf := bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if launched == "" {
+		t.Fatalf("expected agent relaunch, got %q", buf.String())
+	}
+	briefRaw, err := os.ReadFile(launched)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(briefRaw), "- Summary: too vague") {
+		t.Fatalf("expected feedback in brief, got %q", briefRaw)
+	}
+	if review := suggestions.Open(suggPath).Suggestion.Reviews[suggestions.SectionSummary]; review.Reason != "too vague" {
+		t.Fatalf("expected reason recorded, got %+v", review)
+	}
+	files, err := suggestions.List(suggDir, "PRD-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || files[1].Suggestion.Parent != filepath.Base(suggPath) {
+		t.Fatalf("expected linked follow-up, got %+v", files)
+	}
+	cmd = SuggestionsCmd()
+	cmd.SetArgs([]string{"reject", "PRD-001", "--reason", "nope=bad"})
+	cmd.SetOut(bytes.NewBuffer(nil))
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "unknown section") {
+		t.Fatalf("expected unknown section error, got %v", err)
+	}
+}
+
+func TestSuggestionsCompareLinesUpAgents(t *testing.T) {
+	root := t.TempDir()
+	suggDir := filepath.Join(root, ".praude", "suggestions")
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	spec := specs.Spec{ID: "PRD-001", Title: "Alpha", Summary: "Old"}
+	if err := specs.SaveSpec(filepath.Join(root, ".praude", "specs", "PRD-001.yaml"), spec); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	for _, agent := range []string{"codex", "claude"} {
+		path, err := suggestions.CreateFromSpec(suggDir, spec, agent, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		filled := strings.Replace(string(raw), "# summary: \"\"", "summary: \"Summary from "+agent+"\"", 1)
+		if filled == string(raw) {
+			t.Fatalf("expected summary placeholder in %s", raw)
+		}
+		if err := os.WriteFile(path, []byte(filled), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	cmd := SuggestionsCmd()
+	cmd.SetArgs([]string{"compare", "PRD-001"})
+	buf := bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Comparing PRD-001:", "Summary from codex", "Summary from claude", "Cherry-pick with: praude suggestions apply PRD-001 --file"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in %q", want, out)
+		}
+	}
+}
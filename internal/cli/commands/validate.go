@@ -45,10 +45,9 @@ func ValidateCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			res, err := specs.Validate(raw, specs.ValidationOptions{
-				Mode: specs.ValidationMode(selected),
-				Root: root,
-			})
+			opts := cfg.ValidationOptions(root)
+			opts.Mode = specs.ValidationMode(selected)
+			res, err := specs.Validate(raw, opts)
 			if err != nil {
 				return err
 			}
@@ -47,6 +47,8 @@ func NewRoot() *cobra.Command {
 		commands.ResearchCmd(),
 		commands.SuggestCmd(),
 		commands.SuggestionsCmd(),
+		commands.RunsCmd(),
+		commands.AgentCmd(),
 		commands.ValidateCmd(),
 	)
 	return root
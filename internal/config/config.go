@@ -1,34 +1,124 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/BurntSushi/toml"
+	"github.com/mistakeknot/praude/internal/agents"
+	"github.com/mistakeknot/praude/internal/specs"
 )
 
 type Config struct {
-	ValidationMode string                   `toml:"validation_mode"`
+	ValidationMode string                  `toml:"validation_mode"`
+	Research       ResearchConfig          `toml:"research"`
+	Runs           RunsConfig              `toml:"runs"`
 	Agents         map[string]AgentProfile `toml:"agents"`
 }
 
+type RunsConfig struct {
+	MaxConcurrent int `toml:"max_concurrent"`
+}
+
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	value, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	d.Duration = value
+	return nil
+}
+
+type ResearchConfig struct {
+	ConfidenceScale []string `toml:"confidence_scale"`
+	MaxAgeDays      int      `toml:"max_age_days"`
+}
+
 type AgentProfile struct {
-	Command string   `toml:"command"`
-	Args    []string `toml:"args"`
+	Command    string            `toml:"command"`
+	Args       []string          `toml:"args"`
+	Env        map[string]string `toml:"env"`
+	Subagent   bool              `toml:"subagent"`
+	Brief      string            `toml:"brief"`
+	Workdir    string            `toml:"workdir"`
+	Mode       string            `toml:"mode"`
+	Sandbox    string            `toml:"sandbox"`
+	Timeout    Duration          `toml:"timeout"`
+	MaxRetries int               `toml:"max_retries"`
+	ScanBudget int               `toml:"scan_budget"`
 }
 
+func (p AgentProfile) Profile() agents.Profile {
+	return agents.Profile{
+		Command:    p.Command,
+		Args:       p.Args,
+		Env:        p.Env,
+		Subagent:   p.Subagent,
+		Brief:      p.Brief,
+		Workdir:    p.Workdir,
+		Mode:       p.Mode,
+		Sandbox:    p.Sandbox,
+		Timeout:    p.Timeout.Duration,
+		MaxRetries: p.MaxRetries,
+		ScanBudget: p.ScanBudget,
+	}
+}
+
+func (c Config) AgentProfiles() map[string]agents.Profile {
+	out := make(map[string]agents.Profile, len(c.Agents))
+	for name, profile := range c.Agents {
+		out[name] = profile.Profile()
+	}
+	if _, ok := out[agents.FakeAgent]; !ok {
+		out[agents.FakeAgent] = agents.FakeProfile()
+	}
+	return out
+}
+
+const (
+	DefaultResearchMaxAgeDays = 180
+	DefaultMaxConcurrentRuns  = 4
+)
+
 const DefaultConfigToml = `# Praude configuration
 
 validation_mode = "soft"
 
+[research]
+confidence_scale = ["low", "medium", "high"]
+# Market research claims older than this are reported as stale; 0 disables.
+max_age_days = 180
+
+[runs]
+# Agents beyond this many wait in a local queue until a slot frees up.
+max_concurrent = 4
+
 [agents.codex]
 command = "codex"
 args = []
 # args = ["--profile", "pm", "--prompt-file", "{brief}"]
+# Placeholders: {brief}, {spec}, {id}, {output}, {root}, {brief_contents}.
+# The brief path is appended to args unless one of them uses a placeholder.
+# env = { PRAUDE_SPEC = "{spec}" }
+# brief = "arg"       # how the brief is delivered: arg, stdin or env (PRAUDE_BRIEF)
+# workdir = "{root}"  # directory the agent runs in
+# mode = "headless"   # or "interactive" to attach the agent to your terminal
+# sandbox = "copy"    # run in a copied directory or git "worktree"; import only the artifact
+# subagent = false    # set PRAUDE_SUBAGENT=1 for the agent
+# timeout = "30m"     # kill the agent if it runs longer than this
+# max_retries = 1     # retry failed or timed out runs, with exponential backoff
+# scan_budget = 16384 # bytes of ranked repository excerpts in briefs; -1 to omit
 
 [agents.claude]
 command = "claude"
 args = []
+subagent = true
 # args = ["--profile", "pm", "--prompt-file", "{brief}"]
 
 [agents.opencode]
@@ -38,6 +128,9 @@ args = []
 [agents.droid]
 command = "droid"
 args = []
+
+# A built-in "fake" profile (praude agent fake) fills research and suggestion
+# templates with deterministic content, for offline demos and tests.
 `
 
 func LoadFromRoot(root string) (Config, error) {
@@ -47,8 +140,45 @@ func LoadFromRoot(root string) (Config, error) {
 		return Config{}, err
 	}
 	var cfg Config
-	if err := toml.Unmarshal(raw, &cfg); err != nil {
+	meta, err := toml.Decode(string(raw), &cfg)
+	if err != nil {
 		return Config{}, err
 	}
+	for name, profile := range cfg.Agents {
+		if err := profile.Profile().Validate(); err != nil {
+			return Config{}, fmt.Errorf("agents.%s: %w", name, err)
+		}
+	}
+	if cfg.Runs.MaxConcurrent == 0 {
+		cfg.Runs.MaxConcurrent = DefaultMaxConcurrentRuns
+	}
+	if !meta.IsDefined("research", "max_age_days") {
+		cfg.Research.MaxAgeDays = DefaultResearchMaxAgeDays
+	}
 	return cfg, nil
 }
+
+func Default() Config {
+	return Config{
+		ValidationMode: string(specs.ValidationSoft),
+		Research:       ResearchConfig{ConfidenceScale: specs.DefaultConfidenceScale, MaxAgeDays: DefaultResearchMaxAgeDays},
+		Runs:           RunsConfig{MaxConcurrent: DefaultMaxConcurrentRuns},
+	}
+}
+
+func (c Config) ValidationOptions(root string) specs.ValidationOptions {
+	return specs.ValidationOptions{
+		Mode:            specs.ValidationMode(c.ValidationMode),
+		Root:            root,
+		ConfidenceScale: c.Research.ConfidenceScale,
+		MaxAgeDays:      c.Research.MaxAgeDays,
+	}
+}
+
+func LoadValidationOptions(root string) specs.ValidationOptions {
+	cfg, err := LoadFromRoot(root)
+	if err != nil {
+		cfg = Default()
+	}
+	return cfg.ValidationOptions(root)
+}
@@ -5,6 +5,10 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/agents"
+	"github.com/mistakeknot/praude/internal/specs"
 )
 
 func TestDefaultConfigHasAgents(t *testing.T) {
@@ -37,3 +41,157 @@ func TestLoadConfigReadsValidationMode(t *testing.T) {
 		t.Fatalf("expected hard mode, got %q", cfg.ValidationMode)
 	}
 }
+
+func TestLoadConfigDefaultsResearchMaxAge(t *testing.T) {
+	root := t.TempDir()
+	cfgDir := filepath.Join(root, ".praude")
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.toml"), []byte(DefaultConfigToml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadFromRoot(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Research.MaxAgeDays != DefaultResearchMaxAgeDays {
+		t.Fatalf("expected default max age, got %d", cfg.Research.MaxAgeDays)
+	}
+	if len(cfg.Research.ConfidenceScale) != 3 {
+		t.Fatalf("expected confidence scale, got %v", cfg.Research.ConfidenceScale)
+	}
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.toml"), []byte("[research]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if cfg, err = LoadFromRoot(root); err != nil || cfg.Research.MaxAgeDays != DefaultResearchMaxAgeDays {
+		t.Fatalf("expected default max age when unset, got %d (%v)", cfg.Research.MaxAgeDays, err)
+	}
+}
+
+func TestLoadConfigRejectsUnknownPlaceholder(t *testing.T) {
+	root := t.TempDir()
+	cfgDir := filepath.Join(root, ".praude")
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	raw := "[agents.codex]\ncommand = \"codex\"\nargs = [\"--prompt-file\", \"{brief}\"]\nenv = { SPEC = \"{specs}\" }\n"
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.toml"), []byte(raw), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := LoadFromRoot(root)
+	if err == nil || !strings.Contains(err.Error(), "agents.codex: unknown placeholder {specs}") {
+		t.Fatalf("expected placeholder error, got %v", err)
+	}
+}
+
+func TestLoadConfigReadsRunLimits(t *testing.T) {
+	root := t.TempDir()
+	cfgDir := filepath.Join(root, ".praude")
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	raw := "[agents.codex]\ncommand = \"codex\"\ntimeout = \"90s\"\nmax_retries = 2\n"
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.toml"), []byte(raw), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadFromRoot(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile := cfg.AgentProfiles()["codex"]
+	if profile.Timeout != 90*time.Second || profile.MaxRetries != 2 {
+		t.Fatalf("unexpected profile %+v", profile)
+	}
+	if cfg.Runs.MaxConcurrent != DefaultMaxConcurrentRuns {
+		t.Fatalf("expected default concurrency, got %d", cfg.Runs.MaxConcurrent)
+	}
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.toml"), []byte("[agents.codex]\ncommand = \"codex\"\ntimeout = \"soon\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFromRoot(root); err == nil {
+		t.Fatalf("expected invalid timeout to fail")
+	}
+}
+
+func TestAgentProfilesIncludeBuiltinFake(t *testing.T) {
+	cfg := Config{Agents: map[string]AgentProfile{"codex": {Command: "codex"}}}
+	profiles := cfg.AgentProfiles()
+	fake, ok := profiles["fake"]
+	if !ok || len(fake.Args) < 2 || fake.Args[0] != "agent" || fake.Args[1] != "fake" {
+		t.Fatalf("expected built-in fake profile, got %+v", profiles)
+	}
+	cfg.Agents["fake"] = AgentProfile{Command: "my-fake"}
+	if got := cfg.AgentProfiles()["fake"].Command; got != "my-fake" {
+		t.Fatalf("expected configured fake profile to win, got %q", got)
+	}
+}
+
+func TestAgentProfilesReadScanBudget(t *testing.T) {
+	root := t.TempDir()
+	cfgDir := filepath.Join(root, ".praude")
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	raw := "[agents.codex]\ncommand = \"codex\"\nscan_budget = 4096\n\n[agents.claude]\ncommand = \"claude\"\nscan_budget = -1\n\n[agents.droid]\ncommand = \"droid\"\n"
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.toml"), []byte(raw), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadFromRoot(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	profiles := cfg.AgentProfiles()
+	if got := profiles["codex"].ScanBytes(); got != 4096 {
+		t.Fatalf("expected configured budget, got %d", got)
+	}
+	if got := profiles["claude"].ScanBytes(); got != 0 {
+		t.Fatalf("expected disabled budget, got %d", got)
+	}
+	if got := profiles["droid"].ScanBytes(); got != agents.DefaultScanBudget {
+		t.Fatalf("expected default budget, got %d", got)
+	}
+}
+
+func TestDefaultConfigEnablesSubagentForClaude(t *testing.T) {
+	root := t.TempDir()
+	cfgDir := filepath.Join(root, ".praude")
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.toml"), []byte(DefaultConfigToml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadFromRoot(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	profiles := cfg.AgentProfiles()
+	if !profiles["claude"].Subagent || profiles["codex"].Subagent {
+		t.Fatalf("expected only the claude profile to set subagent, got %+v", profiles)
+	}
+	cfg = Config{Agents: map[string]AgentProfile{"claude": {Command: "claude"}}}
+	if cfg.AgentProfiles()["claude"].Subagent {
+		t.Fatalf("expected subagent to default to false without the flag")
+	}
+}
+
+func TestLoadValidationOptionsUsesConfigOrDefaults(t *testing.T) {
+	root := t.TempDir()
+	opts := LoadValidationOptions(root)
+	if opts.Mode != specs.ValidationSoft || opts.MaxAgeDays != DefaultResearchMaxAgeDays || len(opts.ConfidenceScale) != 3 || opts.Root != root {
+		t.Fatalf("expected defaults without a config, got %+v", opts)
+	}
+	cfgDir := filepath.Join(root, ".praude")
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := "validation_mode = \"hard\"\n\n[research]\nconfidence_scale = [\"weak\", \"strong\"]\nmax_age_days = 0\n"
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.toml"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	opts = LoadValidationOptions(root)
+	if opts.Mode != specs.ValidationHard || opts.MaxAgeDays != 0 || len(opts.ConfidenceScale) != 2 {
+		t.Fatalf("expected configured options, got %+v", opts)
+	}
+}
@@ -0,0 +1,115 @@
+package fakeagent
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/ingest"
+	"github.com/mistakeknot/praude/internal/research"
+	"github.com/mistakeknot/praude/internal/specs"
+	"github.com/mistakeknot/praude/internal/suggestions"
+)
+
+func writeBrief(t *testing.T, root string) string {
+	t.Helper()
+	path := filepath.Join(root, ".praude", "brief.md")
+	if err := os.WriteFile(path, []byte("brief\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func setupSpec(t *testing.T) (string, specs.Spec) {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".praude", "config.toml"), []byte("validation_mode = \"hard\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	spec := specs.Spec{ID: "PRD-001", Title: "Alpha", Summary: "Summary", Requirements: []string{"REQ-001: Import"}}
+	if err := specs.SaveSpec(filepath.Join(root, ".praude", "specs", "PRD-001.yaml"), spec); err != nil {
+		t.Fatal(err)
+	}
+	return root, spec
+}
+
+func TestFillResearchTemplateDeterministically(t *testing.T) {
+	root, _ := setupSpec(t)
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	target, err := research.CreateForSpec(root, "PRD-001", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	brief := writeBrief(t, root)
+	var outputs [][]byte
+	for i := 0; i < 2; i++ {
+		if err := Fill(root, brief, "PRD-001", target, now); err != nil {
+			t.Fatal(err)
+		}
+		raw, err := os.ReadFile(target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		outputs = append(outputs, raw)
+	}
+	if !bytes.Equal(outputs[0], outputs[1]) {
+		t.Fatalf("expected identical output across runs")
+	}
+	report, err := ingest.Check(root, "research", "PRD-001", target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Status != ingest.StatusReady || len(report.Errors) != 0 {
+		t.Fatalf("expected ready research, got %+v", report)
+	}
+	findings := research.Parse(outputs[0])
+	if len(findings.MarketResearch) != 1 || len(findings.CompetitiveLandscape) != 1 || len(findings.OSSLandscape) != 1 {
+		t.Fatalf("expected one finding per section, got %+v", findings)
+	}
+}
+
+func TestFillSuggestionTemplate(t *testing.T) {
+	root, spec := setupSpec(t)
+	target, err := suggestions.CreateFromSpec(filepath.Join(root, ".praude", "suggestions"), spec, "fake", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Fill(root, writeBrief(t, root), "PRD-001", target, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	report, err := ingest.Check(root, "suggest", "PRD-001", target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Status != ingest.StatusReady || len(report.Errors) != 0 || report.Summary != "3 suggested changes" {
+		t.Fatalf("expected three valid suggestions, got %+v", report)
+	}
+	sugg, err := suggestions.Load(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sugg.Agent != "fake" || len(sugg.Requirements) != 1 || specs.RequirementID(sugg.Requirements[0].Value) != "REQ-002" {
+		t.Fatalf("expected REQ-002 from the fake agent, got %+v", sugg)
+	}
+}
+
+func TestFillRequiresSpec(t *testing.T) {
+	root, spec := setupSpec(t)
+	target, err := suggestions.CreateFromSpec(filepath.Join(root, ".praude", "suggestions"), spec, "fake", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	brief := writeBrief(t, root)
+	if err := Fill(root, brief, "PRD-404", target, time.Now()); err == nil {
+		t.Fatalf("expected error for an unknown spec")
+	}
+	if err := Fill(root, filepath.Join(root, "missing.md"), "PRD-001", target, time.Now()); err == nil || !strings.Contains(err.Error(), "brief not found") {
+		t.Fatalf("expected error for a missing brief, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package git
 import (
 	"fmt"
 	"os/exec"
+	"strings"
 )
 
 func CommitFiles(root string, files []string, message string) error {
@@ -23,3 +24,27 @@ func EnsureRepo(root string) error {
 	}
 	return nil
 }
+
+func UserName(root string) string {
+	out, err := exec.Command("git", "-C", root, "config", "user.name").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func AddWorktree(root, dir string) error {
+	out, err := exec.Command("git", "-C", root, "worktree", "add", "--detach", dir, "HEAD").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git worktree add: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func RemoveWorktree(root, dir string) error {
+	out, err := exec.Command("git", "-C", root, "worktree", "remove", "--force", dir).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git worktree remove: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package ingest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mistakeknot/praude/internal/config"
+	"github.com/mistakeknot/praude/internal/project"
+	"github.com/mistakeknot/praude/internal/research"
+	"github.com/mistakeknot/praude/internal/specs"
+	"github.com/mistakeknot/praude/internal/suggestions"
+)
+
+const (
+	StatusReady   = "ready"
+	StatusInvalid = "invalid"
+	StatusEmpty   = "empty"
+	StatusError   = "error"
+)
+
+type Report struct {
+	Kind     string   `yaml:"kind"`
+	SpecID   string   `yaml:"spec_id"`
+	Artifact string   `yaml:"artifact"`
+	Status   string   `yaml:"status"`
+	Summary  string   `yaml:"summary,omitempty"`
+	Errors   []string `yaml:"errors,omitempty"`
+	Warnings []string `yaml:"warnings,omitempty"`
+}
+
+func (r Report) Ready() bool {
+	return r.Status == StatusReady
+}
+
+func (r Report) Message() string {
+	name := filepath.Base(r.Artifact)
+	switch r.Status {
+	case StatusEmpty:
+		return fmt.Sprintf("%s for %s has no content yet (%s)", kindLabel(r.Kind), r.SpecID, name)
+	case StatusError:
+		return fmt.Sprintf("Could not read %s: %s", name, r.Summary)
+	}
+	state := "ready for review"
+	if r.Kind == "research" {
+		state = "ready to ingest"
+	}
+	if r.Status == StatusInvalid {
+		state = "would fail validation"
+	}
+	return fmt.Sprintf("%s for %s %s: %s (validation: %s, %s)", kindLabel(r.Kind), r.SpecID, state, r.Summary, plural(len(r.Errors), "error"), plural(len(r.Warnings), "warning"))
+}
+
+func Supported(kind string) bool {
+	return kind == "suggest" || kind == "research"
+}
+
+func Check(root, kind, specID, artifact string) (Report, error) {
+	report := Report{Kind: kind, SpecID: specID, Artifact: artifact}
+	if !Supported(kind) {
+		return report, fmt.Errorf("cannot ingest %s runs", kind)
+	}
+	specPath := filepath.Join(project.SpecsDir(root), specID+".yaml")
+	spec, err := specs.LoadSpec(specPath)
+	if err != nil {
+		return report, err
+	}
+	raw, err := os.ReadFile(artifact)
+	if err != nil {
+		return report, err
+	}
+	switch kind {
+	case "suggest":
+		suggestion, err := suggestions.Load(artifact)
+		if err != nil {
+			report.Status = StatusError
+			report.Summary = err.Error()
+			return report, nil
+		}
+		items := suggestions.Items(suggestion, spec)
+		if len(items) == 0 {
+			report.Status = StatusEmpty
+			return report, nil
+		}
+		report.Summary = plural(len(items), "suggested change")
+		spec = suggestions.Preview(spec, suggestion)
+	case "research":
+		findings := research.Parse(raw)
+		if findings.Empty() {
+			report.Status = StatusEmpty
+			return report, nil
+		}
+		if _, err := research.Merge(&spec, findings, research.RelPath(filepath.Base(artifact))); err != nil {
+			report.Status = StatusError
+			report.Summary = err.Error()
+			return report, nil
+		}
+		report.Summary = fmt.Sprintf("%d market, %d competitive, %d OSS findings", len(findings.MarketResearch), len(findings.CompetitiveLandscape), len(findings.OSSLandscape))
+	}
+	res, err := suggestions.ValidatePreview(spec, config.LoadValidationOptions(root))
+	if err != nil {
+		return report, err
+	}
+	report.Errors, report.Warnings = res.Errors, res.Warnings
+	report.Status = StatusReady
+	if len(res.Errors) > 0 {
+		report.Status = StatusInvalid
+	}
+	return report, nil
+}
+
+func kindLabel(kind string) string {
+	if kind == "research" {
+		return "Research"
+	}
+	return "Suggestions"
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,73 @@
+package ingest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/research"
+)
+
+func writeSpec(t *testing.T, root string) {
+	t.Helper()
+	dir := filepath.Join(root, ".praude", "specs")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	spec := "id: \"PRD-001\"\ntitle: \"Alpha\"\nsummary: \"Old\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "PRD-001.yaml"), []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckSuggestionReportsReady(t *testing.T) {
+	root := t.TempDir()
+	writeSpec(t, root)
+	path := filepath.Join(root, "PRD-001-suggestions.md")
+	raw := "---\nformat: 2\nid: \"PRD-001\"\n---\n## Summary\n~~~yaml\nsummary: \"New\"\n~~~\n"
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	report, err := Check(root, "suggest", "PRD-001", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Ready() || report.Summary != "1 suggested change" {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if !strings.HasPrefix(report.Message(), "Suggestions for PRD-001 ready for review: 1 suggested change") {
+		t.Fatalf("unexpected message %q", report.Message())
+	}
+}
+
+func TestCheckResearchTemplateIsEmpty(t *testing.T) {
+	root := t.TempDir()
+	writeSpec(t, root)
+	path, err := research.CreateForSpec(root, "PRD-001", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := Check(root, "research", "PRD-001", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Status != StatusEmpty {
+		t.Fatalf("expected empty report, got %+v", report)
+	}
+	raw := "## Competitive Analysis\n- Claim: \"Positioned for enterprises\"\n  - name: \"Acme\"\n  - risk: \"high\"\n"
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	report, err = Check(root, "research", "PRD-001", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Status == StatusEmpty || report.Summary != "0 market, 1 competitive, 0 OSS findings" {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if !strings.Contains(report.Message(), "Research for PRD-001") {
+		t.Fatalf("unexpected message %q", report.Message())
+	}
+}
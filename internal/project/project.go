@@ -3,6 +3,7 @@ package project
 import (
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/mistakeknot/praude/internal/config"
 )
@@ -21,6 +22,10 @@ func ResearchDir(root string) string {
 	return filepath.Join(RootDir(root), "research")
 }
 
+func ResearchArchiveDir(root string) string {
+	return filepath.Join(ResearchDir(root), "archive")
+}
+
 func SuggestionsDir(root string) string {
 	return filepath.Join(RootDir(root), "suggestions")
 }
@@ -29,10 +34,24 @@ func BriefsDir(root string) string {
 	return filepath.Join(RootDir(root), "briefs")
 }
 
+func RunsDir(root string) string {
+	return filepath.Join(RootDir(root), "runs")
+}
+
 func ConfigPath(root string) string {
 	return filepath.Join(RootDir(root), "config.toml")
 }
 
+func StampedPath(dir, id string, now time.Time) string {
+	for {
+		path := filepath.Join(dir, id+"-"+now.UTC().Format("20060102-150405")+".md")
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return path
+		}
+		now = now.Add(time.Second)
+	}
+}
+
 func Init(root string) error {
 	dirs := []string{
 		RootDir(root),
@@ -0,0 +1,136 @@
+package research
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mistakeknot/praude/internal/specs"
+)
+
+type MergeStats struct {
+	MarketAdded       int
+	MarketUpdated     int
+	CompetitorAdded   int
+	CompetitorUpdated int
+	OSSAdded          int
+	OSSUpdated        int
+}
+
+func Merge(spec *specs.Spec, findings Findings, artifact string) (MergeStats, error) {
+	stats := MergeStats{}
+	if err := checkDuplicates(findings); err != nil {
+		return stats, err
+	}
+	spec.MarketResearch, stats.MarketAdded, stats.MarketUpdated = mergeFindings(spec.MarketResearch, findings.MarketResearch, artifact, "MR-", marketFields)
+	spec.CompetitiveLandscape, stats.CompetitorAdded, stats.CompetitorUpdated = mergeFindings(spec.CompetitiveLandscape, findings.CompetitiveLandscape, artifact, "COMP-", competitorFields)
+	spec.OSSLandscape, stats.OSSAdded, stats.OSSUpdated = mergeFindings(spec.OSSLandscape, findings.OSSLandscape, artifact, "OSS-", ossFields)
+	if artifact != "" && !contains(spec.Research, artifact) {
+		spec.Research = append(spec.Research, artifact)
+	}
+	return stats, nil
+}
+
+type fields struct {
+	id   *string
+	key  string
+	refs *[]specs.EvidenceRef
+}
+
+func marketFields(item *specs.MarketResearchItem) fields {
+	return fields{id: &item.ID, key: item.Claim, refs: &item.EvidenceRefs}
+}
+
+func competitorFields(item *specs.CompetitiveLandscapeItem) fields {
+	return fields{id: &item.ID, key: item.Name, refs: &item.EvidenceRefs}
+}
+
+func ossFields(item *specs.OSSLandscapeItem) fields {
+	return fields{id: &item.ID, key: item.Name, refs: &item.EvidenceRefs}
+}
+
+func mergeFindings[T any](current, found []T, artifact, prefix string, of func(*T) fields) ([]T, int, int) {
+	added, updated := 0, 0
+	id := func(item T) string { return *of(&item).id }
+	for _, item := range found {
+		f := of(&item)
+		*f.refs = resolveRefs(*f.refs, artifact)
+		if *f.id == "" {
+			*f.id = matchID(current, f.key, of)
+		}
+		if *f.id == "" {
+			*f.id = specs.NextItemID(prefix, itemIDs(current, of))
+		}
+		if idx := specs.IndexByID(current, *f.id, id); idx >= 0 {
+			current[idx] = item
+			updated++
+			continue
+		}
+		current = append(current, item)
+		added++
+	}
+	return current, added, updated
+}
+
+func matchID[T any](items []T, key string, of func(*T) fields) string {
+	if strings.TrimSpace(key) == "" {
+		return ""
+	}
+	for _, item := range items {
+		if f := of(&item); strings.EqualFold(strings.TrimSpace(f.key), strings.TrimSpace(key)) {
+			return *f.id
+		}
+	}
+	return ""
+}
+
+func itemIDs[T any](items []T, of func(*T) fields) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, *of(&item).id)
+	}
+	return ids
+}
+
+func checkDuplicates(findings Findings) error {
+	sections := map[string][]string{
+		"market summary":       itemIDs(findings.MarketResearch, marketFields),
+		"competitive analysis": itemIDs(findings.CompetitiveLandscape, competitorFields),
+		"oss project scan":     itemIDs(findings.OSSLandscape, ossFields),
+	}
+	for section, ids := range sections {
+		seen := make(map[string]bool)
+		for _, id := range ids {
+			if id == "" {
+				continue
+			}
+			if seen[id] {
+				return fmt.Errorf("duplicate id %s in %s", id, section)
+			}
+			seen[id] = true
+		}
+	}
+	return nil
+}
+
+func resolveRefs(refs []specs.EvidenceRef, artifact string) []specs.EvidenceRef {
+	if len(refs) == 0 {
+		return []specs.EvidenceRef{{Path: artifact}}
+	}
+	out := make([]specs.EvidenceRef, 0, len(refs))
+	for _, ref := range refs {
+		if ref.Path == "" || strings.Contains(ref.Path, "YYYYMMDD") {
+			ref.Path = artifact
+		}
+		out = append(out, ref)
+	}
+	return out
+}
+
+func contains(items []string, value string) bool {
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
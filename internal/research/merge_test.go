@@ -0,0 +1,108 @@
+package research
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/specs"
+)
+
+func TestMergeUpdatesByIDAndAssignsNewIDs(t *testing.T) {
+	spec := specs.Spec{
+		ID: "PRD-001",
+		MarketResearch: []specs.MarketResearchItem{
+			{ID: "MR-001", Claim: "Old claim"},
+			{ID: "MR-002", Claim: "Kept claim"},
+		},
+	}
+	findings := Findings{
+		MarketResearch: []specs.MarketResearchItem{
+			{ID: "MR-001", Claim: "Updated claim"},
+			{Claim: "Kept claim", Confidence: "high"},
+			{Claim: "Brand new claim"},
+		},
+		CompetitiveLandscape: []specs.CompetitiveLandscapeItem{
+			{Name: "Acme"},
+		},
+	}
+	artifact := ".praude/research/PRD-001-20260115-000000.md"
+	stats, err := Merge(&spec, findings, artifact)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.MarketUpdated != 2 || stats.MarketAdded != 1 || stats.CompetitorAdded != 1 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if spec.MarketResearch[0].Claim != "Updated claim" {
+		t.Fatalf("expected MR-001 updated")
+	}
+	if spec.MarketResearch[1].Confidence != "high" {
+		t.Fatalf("expected claim matched by text")
+	}
+	if spec.MarketResearch[2].ID != "MR-003" {
+		t.Fatalf("expected next id, got %s", spec.MarketResearch[2].ID)
+	}
+	if spec.MarketResearch[2].EvidenceRefs[0].Path != artifact {
+		t.Fatalf("expected evidence ref to artifact")
+	}
+	if spec.CompetitiveLandscape[0].ID != "COMP-001" {
+		t.Fatalf("expected competitor id assigned")
+	}
+	if len(spec.Research) != 1 || spec.Research[0] != artifact {
+		t.Fatalf("expected artifact registered, got %v", spec.Research)
+	}
+	if _, err := Merge(&spec, Findings{}, artifact); err != nil {
+		t.Fatal(err)
+	}
+	if len(spec.Research) != 1 {
+		t.Fatalf("expected artifact registered once")
+	}
+}
+
+func TestMergeKeepsCuratedItemsWhenTemplateIsFilled(t *testing.T) {
+	dir := t.TempDir()
+	path, err := Create(dir, "PRD-001", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filled := strings.NewReplacer(placeholderMarketClaim, "Demand is rising", placeholderCompetitorClaim, "Cheap and fast", placeholderOSSProject, "Tool").Replace(string(raw))
+	spec := specs.Spec{
+		ID:                   "PRD-001",
+		MarketResearch:       []specs.MarketResearchItem{{ID: "MR-001", Claim: "Curated claim"}},
+		CompetitiveLandscape: []specs.CompetitiveLandscapeItem{{ID: "COMP-001", Name: "Curated competitor"}},
+		OSSLandscape:         []specs.OSSLandscapeItem{{ID: "OSS-001", Name: "Curated project"}},
+	}
+	stats, err := Merge(&spec, Parse([]byte(filled)), RelPath("PRD-001-20260115-000000.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.MarketAdded != 1 || stats.CompetitorAdded != 1 || stats.OSSAdded != 1 || stats.MarketUpdated+stats.CompetitorUpdated+stats.OSSUpdated != 0 {
+		t.Fatalf("expected additions only, got %+v", stats)
+	}
+	if spec.MarketResearch[0].Claim != "Curated claim" || spec.MarketResearch[1].ID != "MR-002" {
+		t.Fatalf("expected curated claim kept, got %+v", spec.MarketResearch)
+	}
+	if spec.CompetitiveLandscape[0].Name != "Curated competitor" || spec.OSSLandscape[0].Name != "Curated project" {
+		t.Fatalf("expected curated entries kept")
+	}
+}
+
+func TestMergeRejectsDuplicateIDs(t *testing.T) {
+	spec := specs.Spec{ID: "PRD-001", MarketResearch: []specs.MarketResearchItem{{ID: "MR-001", Claim: "Curated"}}}
+	findings := Findings{MarketResearch: []specs.MarketResearchItem{
+		{ID: "MR-002", Claim: "First"},
+		{ID: "MR-002", Claim: "Second"},
+	}}
+	if _, err := Merge(&spec, findings, ""); err == nil || !strings.Contains(err.Error(), "MR-002") {
+		t.Fatalf("expected duplicate id error, got %v", err)
+	}
+	if len(spec.MarketResearch) != 1 || spec.MarketResearch[0].Claim != "Curated" {
+		t.Fatalf("expected spec untouched, got %+v", spec.MarketResearch)
+	}
+}
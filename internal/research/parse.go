@@ -0,0 +1,172 @@
+package research
+
+import (
+	"strings"
+
+	"github.com/mistakeknot/praude/internal/specs"
+)
+
+type Findings struct {
+	MarketResearch       []specs.MarketResearchItem
+	CompetitiveLandscape []specs.CompetitiveLandscapeItem
+	OSSLandscape         []specs.OSSLandscapeItem
+}
+
+func (f Findings) Empty() bool {
+	return len(f.MarketResearch) == 0 && len(f.CompetitiveLandscape) == 0 && len(f.OSSLandscape) == 0
+}
+
+type entry struct {
+	value  string
+	fields map[string][]string
+	refs   []specs.EvidenceRef
+}
+
+func (e entry) field(key string) string {
+	values := e.fields[key]
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func Parse(raw []byte) Findings {
+	out := Findings{}
+	for section, entries := range parseSections(string(raw)) {
+		switch section {
+		case "market summary":
+			for _, e := range entries {
+				if isPlaceholder(e.value, placeholderMarketClaim) {
+					continue
+				}
+				out.MarketResearch = append(out.MarketResearch, specs.MarketResearchItem{
+					ID:           e.field("id"),
+					Claim:        e.value,
+					EvidenceRefs: e.refs,
+					Confidence:   e.field("confidence"),
+					Date:         templateValue(e.field("date")),
+				})
+			}
+		case "competitive analysis":
+			for _, e := range entries {
+				if isPlaceholder(e.value, placeholderCompetitorClaim) {
+					continue
+				}
+				out.CompetitiveLandscape = append(out.CompetitiveLandscape, specs.CompetitiveLandscapeItem{
+					ID:           e.field("id"),
+					Name:         e.field("name"),
+					Positioning:  e.value,
+					Strengths:    e.fields["strengths"],
+					Weaknesses:   e.fields["weaknesses"],
+					Risk:         e.field("risk"),
+					EvidenceRefs: e.refs,
+				})
+			}
+		case "oss project scan":
+			for _, e := range entries {
+				if isPlaceholder(e.value, placeholderOSSProject) {
+					continue
+				}
+				out.OSSLandscape = append(out.OSSLandscape, specs.OSSLandscapeItem{
+					ID:                 e.field("id"),
+					Name:               e.value,
+					Repo:               e.field("repo"),
+					License:            e.field("license"),
+					Learnings:          e.fields["learnings"],
+					Insights:           e.fields["insights"],
+					ReusableComponents: e.fields["bootstrapping"],
+					EvidenceRefs:       e.refs,
+				})
+			}
+		}
+	}
+	return out
+}
+
+func parseSections(content string) map[string][]entry {
+	sections := make(map[string][]entry)
+	var section string
+	var current *entry
+	inRefs := false
+	flush := func() {
+		if current != nil && section != "" {
+			sections[section] = append(sections[section], *current)
+		}
+		current = nil
+		inRefs = false
+	}
+	for _, line := range strings.Split(content, "\n") {
+		trim := strings.TrimSpace(line)
+		if strings.HasPrefix(trim, "## ") {
+			flush()
+			section = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(trim, "## ")))
+			continue
+		}
+		if !strings.HasPrefix(trim, "- ") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		key, value := splitField(strings.TrimPrefix(trim, "- "))
+		switch {
+		case indent == 0:
+			flush()
+			current = &entry{value: value, fields: make(map[string][]string)}
+		case current == nil:
+			continue
+		case strings.EqualFold(key, "evidence refs"):
+			inRefs = true
+		case inRefs && indent > 2:
+			addRefField(current, strings.ToLower(key), value)
+		default:
+			inRefs = false
+			if value != "" {
+				field := strings.ToLower(key)
+				current.fields[field] = append(current.fields[field], value)
+			}
+		}
+	}
+	flush()
+	return sections
+}
+
+func addRefField(e *entry, key, value string) {
+	if key == "path" || len(e.refs) == 0 {
+		e.refs = append(e.refs, specs.EvidenceRef{})
+	}
+	ref := &e.refs[len(e.refs)-1]
+	switch key {
+	case "path":
+		ref.Path = value
+	case "anchor":
+		ref.Anchor = value
+	case "note":
+		ref.Note = value
+	}
+}
+
+func splitField(text string) (string, string) {
+	idx := strings.Index(text, ":")
+	if idx < 0 {
+		return "", unquote(text)
+	}
+	return strings.TrimSpace(text[:idx]), unquote(text[idx+1:])
+}
+
+func unquote(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+func isPlaceholder(value, placeholder string) bool {
+	return strings.TrimSpace(value) == "" || value == placeholder
+}
+
+func templateValue(value string) string {
+	if strings.Contains(value, "YYYY") {
+		return ""
+	}
+	return value
+}
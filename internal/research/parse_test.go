@@ -0,0 +1,81 @@
+package research
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestParseSkipsTemplatePlaceholders(t *testing.T) {
+	dir := t.TempDir()
+	path, err := Create(dir, "PRD-001", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	findings := Parse(raw)
+	if len(findings.MarketResearch) != 0 || len(findings.CompetitiveLandscape) != 0 || len(findings.OSSLandscape) != 0 {
+		t.Fatalf("expected template to yield no findings, got %+v", findings)
+	}
+}
+
+func TestParseReadsClaimsAndEvidence(t *testing.T) {
+	raw := []byte(`# Research for PRD-001
+
+## Market Summary
+- Claim: "Teams adopt PRD tooling"
+  - id: "MR-002"
+  - confidence: "high"
+  - date: "2026-01-10"
+  - Evidence refs:
+    - path: ".praude/research/PRD-001-20260115-000000.md"
+    - anchor: "survey"
+    - note: "62% of teams"
+    - path: ".praude/research/PRD-001-20260115-000000.md"
+    - anchor: "report"
+
+## Competitive Analysis
+- Claim: "Positioned for enterprises"
+  - name: "Acme"
+  - strengths: "Integrations"
+  - strengths: "Brand"
+  - weaknesses: "Price"
+  - risk: "high"
+
+## OSS project scan
+- Project: "prdkit"
+  - repo: "https://github.com/example/prdkit"
+  - license: "MIT"
+  - learnings: "Keep schemas small"
+  - bootstrapping: "Reuse the validator"
+  - insights: "CLI first"
+`)
+	findings := Parse(raw)
+	if len(findings.MarketResearch) != 1 {
+		t.Fatalf("expected one market claim, got %d", len(findings.MarketResearch))
+	}
+	claim := findings.MarketResearch[0]
+	if claim.ID != "MR-002" || claim.Confidence != "high" || claim.Date != "2026-01-10" {
+		t.Fatalf("unexpected claim: %+v", claim)
+	}
+	if len(claim.EvidenceRefs) != 2 || claim.EvidenceRefs[0].Note != "62% of teams" || claim.EvidenceRefs[1].Anchor != "report" {
+		t.Fatalf("unexpected evidence refs: %+v", claim.EvidenceRefs)
+	}
+	if len(findings.CompetitiveLandscape) != 1 {
+		t.Fatalf("expected one competitor")
+	}
+	comp := findings.CompetitiveLandscape[0]
+	if comp.Name != "Acme" || comp.Positioning != "Positioned for enterprises" || len(comp.Strengths) != 2 {
+		t.Fatalf("unexpected competitor: %+v", comp)
+	}
+	if len(findings.OSSLandscape) != 1 {
+		t.Fatalf("expected one oss project")
+	}
+	oss := findings.OSSLandscape[0]
+	if oss.Name != "prdkit" || oss.License != "MIT" || len(oss.Learnings) != 1 || len(oss.Insights) != 1 || len(oss.ReusableComponents) != 1 {
+		t.Fatalf("unexpected oss project: %+v", oss)
+	}
+}
@@ -0,0 +1,179 @@
+package research
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/git"
+	"github.com/mistakeknot/praude/internal/project"
+	"github.com/mistakeknot/praude/internal/specs"
+)
+
+type Status string
+
+const (
+	StatusTemplate Status = "template-only"
+	StatusFilled   Status = "filled"
+	StatusCited    Status = "cited"
+)
+
+type Artifact struct {
+	Name       string
+	Path       string
+	Ref        string
+	SpecID     string
+	Status     Status
+	Registered bool
+	ModTime    time.Time
+}
+
+func CreateForSpec(root, id string, now time.Time) (string, error) {
+	dir := project.ResearchDir(root)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path, err := Create(dir, id, now)
+	if err != nil {
+		return "", err
+	}
+	specPath := filepath.Join(project.SpecsDir(root), id+".yaml")
+	if _, err := os.Stat(specPath); err != nil {
+		return path, nil
+	}
+	if err := specs.RegisterResearch(specPath, RelPath(filepath.Base(path))); err != nil {
+		return path, err
+	}
+	if err := git.EnsureRepo(root); err == nil {
+		_ = git.CommitFiles(root, []string{specPath}, "chore(praude): register research "+id)
+	}
+	return path, nil
+}
+
+func List(dir, id string, spec specs.Spec) ([]Artifact, error) {
+	all, err := scanArtifacts(dir)
+	if err != nil {
+		return nil, err
+	}
+	var out []Artifact
+	for _, artifact := range all {
+		if artifact.SpecID != id {
+			continue
+		}
+		out = append(out, classify(artifact, []specs.Spec{spec}))
+	}
+	return out, nil
+}
+
+func Unused(dir string, all []specs.Spec, minAge time.Duration, now time.Time) ([]Artifact, error) {
+	artifacts, err := scanArtifacts(dir)
+	if err != nil {
+		return nil, err
+	}
+	var out []Artifact
+	for _, artifact := range artifacts {
+		if now.Sub(artifact.ModTime) < minAge {
+			continue
+		}
+		artifact = classify(artifact, all)
+		if artifact.Status != StatusCited && (!artifact.Registered || artifact.Status == StatusTemplate) {
+			out = append(out, artifact)
+		}
+	}
+	return out, nil
+}
+
+func Archive(root string, artifact Artifact) (string, error) {
+	archiveDir := project.ResearchArchiveDir(root)
+	if err := os.MkdirAll(archiveDir, 0o755); err != nil {
+		return "", err
+	}
+	target := filepath.Join(archiveDir, artifact.Name)
+	if err := os.Rename(artifact.Path, target); err != nil {
+		return "", err
+	}
+	specPath := filepath.Join(project.SpecsDir(root), artifact.SpecID+".yaml")
+	if artifact.Registered {
+		if _, err := os.Stat(specPath); err == nil {
+			if err := specs.UnregisterResearch(specPath, artifact.Ref); err != nil {
+				return target, err
+			}
+		}
+	}
+	return target, nil
+}
+
+func SpecIDFromName(name string) string {
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+	parts := strings.Split(base, "-")
+	if len(parts) < 3 {
+		return base
+	}
+	return strings.Join(parts[:len(parts)-2], "-")
+}
+
+func scanArtifacts(dir string) ([]Artifact, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var out []Artifact
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		out = append(out, Artifact{
+			Name:    entry.Name(),
+			Path:    filepath.Join(dir, entry.Name()),
+			Ref:     RelPath(entry.Name()),
+			SpecID:  SpecIDFromName(entry.Name()),
+			ModTime: info.ModTime(),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+func classify(artifact Artifact, all []specs.Spec) Artifact {
+	for _, spec := range all {
+		if contains(spec.Research, artifact.Ref) {
+			artifact.Registered = true
+		}
+		if citesArtifact(spec, artifact.Ref) {
+			artifact.Status = StatusCited
+		}
+	}
+	if artifact.Status == StatusCited {
+		return artifact
+	}
+	artifact.Status = StatusTemplate
+	if raw, err := os.ReadFile(artifact.Path); err == nil && !Parse(raw).Empty() {
+		artifact.Status = StatusFilled
+	}
+	return artifact
+}
+
+func citesArtifact(spec specs.Spec, ref string) bool {
+	var refs []specs.EvidenceRef
+	for _, item := range spec.MarketResearch {
+		refs = append(refs, item.EvidenceRefs...)
+	}
+	for _, item := range spec.CompetitiveLandscape {
+		refs = append(refs, item.EvidenceRefs...)
+	}
+	for _, item := range spec.OSSLandscape {
+		refs = append(refs, item.EvidenceRefs...)
+	}
+	for _, evidence := range refs {
+		if filepath.ToSlash(filepath.Clean(evidence.Path)) == ref {
+			return true
+		}
+	}
+	return false
+}
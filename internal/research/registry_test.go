@@ -0,0 +1,188 @@
+package research
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/specs"
+)
+
+func TestCreateForSpecRegistersArtifact(t *testing.T) {
+	root := t.TempDir()
+	specsDir := filepath.Join(root, ".praude", "specs")
+	if err := os.MkdirAll(specsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	specPath := filepath.Join(specsDir, "PRD-001.yaml")
+	if err := os.WriteFile(specPath, []byte("id: \"PRD-001\"\ntitle: \"Alpha\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	path, err := CreateForSpec(root, "PRD-001", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec, err := specs.LoadSpec(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spec.Research) != 1 || spec.Research[0] != RelPath(filepath.Base(path)) {
+		t.Fatalf("expected artifact registered, got %v", spec.Research)
+	}
+}
+
+func TestCreateForSpecCommitsRegistration(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	root := t.TempDir()
+	specsDir := filepath.Join(root, ".praude", "specs")
+	if err := os.MkdirAll(specsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(specsDir, "PRD-001.yaml"), []byte("id: \"PRD-001\"\ntitle: \"Alpha\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git := func(args ...string) string {
+		out, err := exec.Command("git", append([]string{"-C", root, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return string(out)
+	}
+	git("init", "-q")
+	git("add", ".")
+	git("commit", "-q", "-m", "init")
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	if _, err := CreateForSpec(root, "PRD-001", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if status := git("status", "--porcelain", "--", ".praude/specs"); status != "" {
+		t.Fatalf("expected registration committed, got %q", status)
+	}
+}
+
+func TestListClassifiesArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	templatePath, err := Create(dir, "PRD-001", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filledPath := filepath.Join(dir, "PRD-001-20260116-000000.md")
+	if err := os.WriteFile(filledPath, []byte("## Market Summary\n- Claim: \"Real claim\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	citedPath := filepath.Join(dir, "PRD-001-20260117-000000.md")
+	if err := os.WriteFile(citedPath, []byte("## Market Summary\n- Claim: \"Cited claim\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "PRD-002-20260117-000000.md"), []byte(""), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	spec := specs.Spec{
+		ID:       "PRD-001",
+		Research: []string{RelPath(filepath.Base(templatePath))},
+		MarketResearch: []specs.MarketResearchItem{{
+			ID:           "MR-001",
+			EvidenceRefs: []specs.EvidenceRef{{Path: RelPath(filepath.Base(citedPath))}},
+		}},
+	}
+	artifacts, err := List(dir, "PRD-001", spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(artifacts) != 3 {
+		t.Fatalf("expected 3 artifacts, got %d", len(artifacts))
+	}
+	want := []Status{StatusTemplate, StatusFilled, StatusCited}
+	for i, artifact := range artifacts {
+		if artifact.Status != want[i] {
+			t.Fatalf("artifact %s: expected %s, got %s", artifact.Name, want[i], artifact.Status)
+		}
+	}
+	if !artifacts[0].Registered || artifacts[1].Registered {
+		t.Fatalf("unexpected registration flags")
+	}
+}
+
+func TestUnusedAndArchiveMoveArtifactsAndUnregister(t *testing.T) {
+	root := t.TempDir()
+	specsDir := filepath.Join(root, ".praude", "specs")
+	researchDir := filepath.Join(root, ".praude", "research")
+	if err := os.MkdirAll(specsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(specsDir, "PRD-001.yaml"), []byte("id: \"PRD-001\"\ntitle: \"Alpha\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	templatePath, err := CreateForSpec(root, "PRD-001", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orphan := filepath.Join(researchDir, "PRD-009-20260115-000000.md")
+	if err := os.WriteFile(orphan, []byte("## Market Summary\n- Claim: \"Orphan\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	spec, err := specs.LoadSpec(filepath.Join(specsDir, "PRD-001.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(48 * time.Hour)
+	unused, err := Unused(researchDir, []specs.Spec{spec}, 24*time.Hour, later)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unused) != 2 {
+		t.Fatalf("expected 2 unused artifacts, got %d", len(unused))
+	}
+	if recent, _ := Unused(researchDir, []specs.Spec{spec}, 24*time.Hour, time.Now()); len(recent) != 0 {
+		t.Fatalf("expected recent artifacts to be skipped")
+	}
+	for _, artifact := range unused {
+		if _, err := Archive(root, artifact); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(researchDir, "archive", filepath.Base(templatePath))); err != nil {
+		t.Fatalf("expected template archived: %v", err)
+	}
+	raw, err := os.ReadFile(filepath.Join(specsDir, "PRD-001.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), filepath.Base(templatePath)) {
+		t.Fatalf("expected archived artifact unregistered")
+	}
+}
+
+func TestUnusedKeepsCitedUnregisteredArtifacts(t *testing.T) {
+	researchDir := filepath.Join(t.TempDir(), ".praude", "research")
+	if err := os.MkdirAll(researchDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	name := "PRD-001-20250101-000000.md"
+	if err := os.WriteFile(filepath.Join(researchDir, name), []byte("# Notes written before research was registered\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	spec := specs.Spec{
+		ID: "PRD-001",
+		MarketResearch: []specs.MarketResearchItem{
+			{ID: "MR-001", Claim: "Demand is rising", EvidenceRefs: []specs.EvidenceRef{{Path: RelPath(name)}}},
+		},
+	}
+	unused, err := Unused(researchDir, []specs.Spec{spec}, 0, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unused) != 0 {
+		t.Fatalf("expected cited artifact kept, got %+v", unused)
+	}
+}
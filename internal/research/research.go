@@ -4,37 +4,82 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
+
+	"github.com/mistakeknot/praude/internal/project"
+)
+
+const (
+	placeholderMarketClaim     = "Replace with a specific market claim."
+	placeholderCompetitorClaim = "Replace with a specific competitor claim."
+	placeholderOSSProject      = "Name the OSS project"
 )
 
 func Create(dir, id string, now time.Time) (string, error) {
-	name := fmt.Sprintf("%s-%s.md", id, now.UTC().Format("20060102-150405"))
-	path := filepath.Join(dir, name)
+	path := project.StampedPath(dir, id, now)
+	ref := RelPath(filepath.Base(path))
 	body := fmt.Sprintf(`# Research for %s
 
 ## Market Summary
-- Claim: "Replace with a specific market claim."
+- Claim: "%s"
+  - confidence: "medium"
+  - date: "YYYY-MM-DD"
   - Evidence refs:
-    - path: ".praude/research/%s-YYYYMMDD-HHMMSS.md"
+    - path: "%s"
     - anchor: "section-1"
     - note: "Source quote"
 
 ## Competitive Analysis
-- Claim: "Replace with a specific competitor claim."
+- Claim: "%s"
+  - name: "Competitor name"
+  - strengths: "Strength"
+  - weaknesses: "Weakness"
+  - risk: "medium"
   - Evidence refs:
-    - path: ".praude/research/%s-YYYYMMDD-HHMMSS.md"
+    - path: "%s"
     - anchor: "section-2"
     - note: "Source quote"
 
 ## OSS project scan
-- Project: "Name the OSS project"
+- Project: "%s"
+  - repo: "https://github.com/owner/project"
+  - license: "License"
   - learnings: "Key takeaways"
   - bootstrapping: "What can be reused"
   - insights: "Product or UX notes"
   - Evidence refs:
-    - path: ".praude/research/%s-YYYYMMDD-HHMMSS.md"
+    - path: "%s"
     - anchor: "section-3"
     - note: "Source quote"
-`, id, id, id, id)
+`, id, placeholderMarketClaim, ref, placeholderCompetitorClaim, ref, placeholderOSSProject, ref)
 	return path, os.WriteFile(path, []byte(body), 0o644)
 }
+
+func Latest(dir, id string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	var latest string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if !strings.HasPrefix(name, id+"-") || !strings.HasSuffix(name, ".md") {
+			continue
+		}
+		if name > latest {
+			latest = name
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no research for %s", id)
+	}
+	return filepath.Join(dir, latest), nil
+}
+
+func RelPath(name string) string {
+	return filepath.ToSlash(filepath.Join(project.PraudeDir, "research", name))
+}
@@ -0,0 +1,86 @@
+package runs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/ingest"
+)
+
+var (
+	checkArtifact = ingest.Check
+	targetPoll    = time.Second
+)
+
+func fileHash(path string) string {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+func ingestTarget(run *Run, now time.Time) bool {
+	if run.Target == "" || !ingest.Supported(run.Kind) {
+		return false
+	}
+	hash := fileHash(run.Target)
+	if hash == "" || hash == run.IngestHash {
+		return false
+	}
+	run.IngestHash = hash
+	run.IngestedAt = now.UTC().Format(time.RFC3339)
+	if hash == run.TargetHash {
+		run.Ingest = &ingest.Report{Kind: run.Kind, SpecID: run.SpecID, Artifact: run.Target, Status: ingest.StatusEmpty}
+		return true
+	}
+	report, err := checkArtifact(run.Root, run.Kind, run.SpecID, run.Target)
+	if err != nil {
+		report.Status = ingest.StatusError
+		report.Summary = err.Error()
+	}
+	run.Ingest = &report
+	return true
+}
+
+func watchTarget(run Run, stop <-chan struct{}) {
+	if run.Target == "" || !ingest.Supported(run.Kind) {
+		return
+	}
+	ticker := time.NewTicker(targetPoll)
+	defer ticker.Stop()
+	previous := run.TargetHash
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		hash := fileHash(run.Target)
+		stable := hash != "" && hash == previous
+		previous = hash
+		if !stable || hash == run.TargetHash {
+			continue
+		}
+		_ = ingestActive(run, time.Now())
+	}
+}
+
+func ingestActive(run Run, now time.Time) error {
+	unlock, err := lockRun(run)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	latest, err := Load(run.Path)
+	if err != nil || !latest.Active() {
+		return err
+	}
+	if ingestTarget(&latest, now) {
+		return Save(latest)
+	}
+	return nil
+}
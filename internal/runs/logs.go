@@ -0,0 +1,232 @@
+package runs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	MaxLogBytes = 1 << 20
+	LogBackups  = 3
+)
+
+type rotatingWriter struct {
+	path string
+	max  int64
+	keep int
+	file *os.File
+	size int64
+}
+
+func openLog(path string, max int64, keep int) (*rotatingWriter, error) {
+	w := &rotatingWriter{path: path, max: max, keep: keep}
+	return w, w.open()
+}
+
+func (w *rotatingWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	w.file, w.size = f, info.Size()
+	return nil
+}
+
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+	if w.size > 0 && w.size+int64(len(p)) > w.max {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *rotatingWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	for i := w.keep; i > 0; i-- {
+		src := backupPath(w.path, i-1)
+		if _, err := os.Stat(src); err == nil {
+			if err := os.Rename(src, backupPath(w.path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return w.open()
+}
+
+func (w *rotatingWriter) Close() error {
+	return w.file.Close()
+}
+
+func backupPath(path string, n int) string {
+	if n == 0 {
+		return path
+	}
+	return fmt.Sprintf("%s.%d", path, n)
+}
+
+func LogFiles(run Run) []string {
+	if run.Log == "" {
+		return nil
+	}
+	var out []string
+	for i := LogBackups; i >= 0; i-- {
+		path := backupPath(run.Log, i)
+		if _, err := os.Stat(path); err == nil {
+			out = append(out, path)
+		}
+	}
+	return out
+}
+
+func Tail(run Run, n int) ([]string, error) {
+	if run.Log == "" {
+		return nil, nil
+	}
+	raw, err := os.ReadFile(run.Log)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimRight(string(raw), "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return nil, nil
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines, nil
+}
+
+func Follow(root, id string, out io.Writer, poll time.Duration) error {
+	run, err := Get(root, id)
+	if err != nil {
+		return err
+	}
+	files := LogFiles(run)
+	for i, path := range files {
+		if i == len(files)-1 {
+			break
+		}
+		if _, err := copyFrom(path, 0, out); err != nil {
+			return err
+		}
+	}
+	tail := &logFollower{path: run.Log}
+	defer tail.close()
+	for {
+		if err := tail.poll(out); err != nil {
+			return err
+		}
+		if !run.Active() {
+			return nil
+		}
+		time.Sleep(poll)
+		if run, err = Get(root, id); err != nil {
+			return err
+		}
+	}
+}
+
+type logFollower struct {
+	path   string
+	file   *os.File
+	offset int64
+}
+
+func (f *logFollower) poll(out io.Writer) error {
+	for {
+		if f.file == nil {
+			file, err := os.Open(f.path)
+			if os.IsNotExist(err) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			f.file, f.offset = file, 0
+		}
+		if err := f.drain(out); err != nil {
+			return err
+		}
+		opened, err := f.file.Stat()
+		if err != nil {
+			return err
+		}
+		current, err := os.Stat(f.path)
+		switch {
+		case err != nil && !os.IsNotExist(err):
+			return err
+		case err == nil && !os.SameFile(opened, current):
+			if err := f.drain(out); err != nil {
+				return err
+			}
+			f.close()
+			if err := copyRotated(f.path, opened, out); err != nil {
+				return err
+			}
+		case opened.Size() < f.offset:
+			if _, err := f.file.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			f.offset = 0
+		default:
+			return nil
+		}
+	}
+}
+
+func copyRotated(path string, followed os.FileInfo, out io.Writer) error {
+	newest := LogBackups
+	for i := 1; i <= LogBackups; i++ {
+		if info, err := os.Stat(backupPath(path, i)); err == nil && os.SameFile(followed, info) {
+			newest = i - 1
+			break
+		}
+	}
+	for i := newest; i > 0; i-- {
+		if _, err := copyFrom(backupPath(path, i), 0, out); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *logFollower) drain(out io.Writer) error {
+	n, err := io.Copy(out, f.file)
+	f.offset += n
+	return err
+}
+
+func (f *logFollower) close() {
+	if f.file != nil {
+		f.file.Close()
+		f.file = nil
+	}
+}
+
+func copyFrom(path string, offset int64, out io.Writer) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return io.Copy(out, f)
+}
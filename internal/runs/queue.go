@@ -0,0 +1,97 @@
+package runs
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/config"
+)
+
+var (
+	queuePoll    = time.Second
+	killGrace    = 5 * time.Second
+	retryBackoff = func(attempt int) time.Duration {
+		return time.Duration(1<<uint(attempt-1)) * 5 * time.Second
+	}
+)
+
+func concurrencyLimit(root string) int {
+	cfg, err := config.LoadFromRoot(root)
+	if err != nil || cfg.Runs.MaxConcurrent <= 0 {
+		return config.DefaultMaxConcurrentRuns
+	}
+	return cfg.Runs.MaxConcurrent
+}
+
+func acquireSlot(root string, run *Run) (bool, error) {
+	limit := concurrencyLimit(root)
+	for {
+		ok, err := tryAcquire(root, run, limit)
+		if err != nil || ok {
+			return ok, err
+		}
+		if run.Status == StatusKilled {
+			return false, nil
+		}
+		time.Sleep(queuePoll)
+	}
+}
+
+func tryAcquire(root string, run *Run, limit int) (bool, error) {
+	unlock, err := lockQueue(root)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+	latest, err := Load(run.Path)
+	if err != nil {
+		return false, err
+	}
+	run.Ingest, run.IngestHash, run.IngestedAt = latest.Ingest, latest.IngestHash, latest.IngestedAt
+	if latest.Status == StatusKilled {
+		run.Status = StatusKilled
+		return false, nil
+	}
+	all, err := List(root)
+	if err != nil {
+		return false, err
+	}
+	running := 0
+	for _, other := range all {
+		if other.ID != run.ID && other.Status == StatusRunning {
+			running++
+		}
+	}
+	if running >= limit {
+		if run.Status != StatusQueued {
+			run.Status = StatusQueued
+			return false, Save(*run)
+		}
+		return false, nil
+	}
+	run.Status = StatusRunning
+	return true, Save(*run)
+}
+
+func lockQueue(root string) (func(), error) {
+	if err := os.MkdirAll(Dir(root), 0o755); err != nil {
+		return nil, err
+	}
+	return lockFile(filepath.Join(Dir(root), "queue.lock"))
+}
+
+func lockRun(run Run) (func(), error) {
+	return lockFile(filepath.Join(filepath.Dir(run.Path), "queue.lock"))
+}
+
+func sleepUnlessKilled(run Run, d time.Duration) bool {
+	deadline := time.Now().Add(d)
+	for time.Now().Before(deadline) {
+		if killed(run) {
+			return false
+		}
+		time.Sleep(min(queuePoll, time.Until(deadline)))
+	}
+	return !killed(run)
+}
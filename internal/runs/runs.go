@@ -0,0 +1,213 @@
+package runs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/ingest"
+	"github.com/mistakeknot/praude/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+const stampLayout = "20060102-150405"
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusKilled    Status = "killed"
+	StatusLost      Status = "lost"
+)
+
+type Run struct {
+	ID            string         `yaml:"id"`
+	Kind          string         `yaml:"kind"`
+	SpecID        string         `yaml:"spec_id,omitempty"`
+	Agent         string         `yaml:"agent"`
+	Brief         string         `yaml:"brief"`
+	Target        string         `yaml:"target,omitempty"`
+	TargetHash    string         `yaml:"target_hash,omitempty"`
+	Command       []string       `yaml:"command"`
+	Env           []string       `yaml:"env,omitempty"`
+	Root          string         `yaml:"root"`
+	Dir           string         `yaml:"dir"`
+	Stdin         string         `yaml:"stdin,omitempty"`
+	Interactive   bool           `yaml:"interactive,omitempty"`
+	Sandbox       string         `yaml:"sandbox,omitempty"`
+	SandboxDir    string         `yaml:"sandbox_dir,omitempty"`
+	Discarded     []string       `yaml:"discarded,omitempty"`
+	Timeout       string         `yaml:"timeout,omitempty"`
+	MaxRetries    int            `yaml:"max_retries,omitempty"`
+	Attempt       int            `yaml:"attempt,omitempty"`
+	Log           string         `yaml:"log"`
+	Status        Status         `yaml:"status"`
+	PID           int            `yaml:"pid,omitempty"`
+	SupervisorPID int            `yaml:"supervisor_pid,omitempty"`
+	StartedAt     string         `yaml:"started_at"`
+	EndedAt       string         `yaml:"ended_at,omitempty"`
+	ExitCode      *int           `yaml:"exit_code,omitempty"`
+	Error         string         `yaml:"error,omitempty"`
+	Ingest        *ingest.Report `yaml:"ingest,omitempty"`
+	IngestHash    string         `yaml:"ingest_hash,omitempty"`
+	IngestedAt    string         `yaml:"ingested_at,omitempty"`
+	Path          string         `yaml:"-"`
+}
+
+func (r Run) Active() bool {
+	return r.Status == StatusPending || r.Status == StatusQueued || r.Status == StatusRunning
+}
+
+func (r Run) Duration(now time.Time) time.Duration {
+	start, err := time.Parse(time.RFC3339, r.StartedAt)
+	if err != nil {
+		return 0
+	}
+	if end, err := time.Parse(time.RFC3339, r.EndedAt); err == nil {
+		now = end
+	}
+	return now.Sub(start).Round(time.Second)
+}
+
+func Dir(root string) string {
+	return project.RunsDir(root)
+}
+
+func Create(root string, run Run, now time.Time) (Run, error) {
+	dir := Dir(root)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return run, err
+	}
+	base := now.UTC().Format(stampLayout) + "-" + run.Agent
+	run.ID = base
+	for n := 2; ; n++ {
+		if _, err := os.Stat(filepath.Join(dir, run.ID+".yaml")); os.IsNotExist(err) {
+			break
+		}
+		run.ID = fmt.Sprintf("%s-%d", base, n)
+	}
+	run.Path = filepath.Join(dir, run.ID+".yaml")
+	run.Log = filepath.Join(dir, run.ID+".log")
+	run.Status = StatusPending
+	run.StartedAt = now.UTC().Format(time.RFC3339)
+	return run, Save(run)
+}
+
+func Save(run Run) error {
+	out, err := yaml.Marshal(run)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(run.Path), "."+filepath.Base(run.Path)+"-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(out); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), run.Path)
+}
+
+func Load(path string) (Run, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return Run{}, err
+	}
+	var run Run
+	if err := yaml.Unmarshal(raw, &run); err != nil {
+		return Run{}, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	run.Path = path
+	return refresh(run)
+}
+
+func List(root string) ([]Run, error) {
+	entries, err := os.ReadDir(Dir(root))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []Run
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".yaml") {
+			continue
+		}
+		run, err := Load(filepath.Join(Dir(root), entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, run)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func Active(root string) ([]Run, error) {
+	all, err := List(root)
+	if err != nil {
+		return nil, err
+	}
+	var out []Run
+	for _, run := range all {
+		if run.Active() {
+			out = append(out, run)
+		}
+	}
+	return out, nil
+}
+
+func Get(root, id string) (Run, error) {
+	path := filepath.Join(Dir(root), id+".yaml")
+	if _, err := os.Stat(path); err == nil {
+		return Load(path)
+	}
+	all, err := List(root)
+	if err != nil {
+		return Run{}, err
+	}
+	var matches []Run
+	for _, run := range all {
+		if strings.HasPrefix(run.ID, id) {
+			matches = append(matches, run)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Run{}, fmt.Errorf("run not found: %s", id)
+	case 1:
+		return matches[0], nil
+	default:
+		return Run{}, fmt.Errorf("run %s is ambiguous (%d matches)", id, len(matches))
+	}
+}
+
+func refresh(run Run) (Run, error) {
+	if !run.Active() || alive(run.SupervisorPID) || alive(run.PID) {
+		return run, nil
+	}
+	if run.SupervisorPID == 0 && run.PID == 0 {
+		return run, nil
+	}
+	run.Status = StatusLost
+	run.Error = "supervisor exited without recording a result"
+	return run, Save(run)
+}
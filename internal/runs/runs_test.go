@@ -0,0 +1,735 @@
+package runs
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/agents"
+	"github.com/mistakeknot/praude/internal/ingest"
+)
+
+func superviseInProcess(t *testing.T, async bool) {
+	var wg sync.WaitGroup
+	old := spawnSupervisor
+	spawnSupervisor = func(root, id string) error {
+		if async {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = Supervise(root, id)
+			}()
+			return nil
+		}
+		return Supervise(root, id)
+	}
+	t.Cleanup(func() {
+		wg.Wait()
+		spawnSupervisor = old
+	})
+}
+
+func TestLaunchRecordsExitCode(t *testing.T) {
+	root := t.TempDir()
+	superviseInProcess(t, false)
+	ok, err := Launch(root, Request{Kind: "research", SpecID: "PRD-001", Agent: "sh", Profile: agents.Profile{Command: "true"}, Brief: "brief.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok, err = Get(root, ok.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok.Status != StatusSucceeded || ok.ExitCode == nil || *ok.ExitCode != 0 || ok.EndedAt == "" {
+		t.Fatalf("unexpected run: %+v", ok)
+	}
+	failed, err := Launch(root, Request{Kind: "research", Agent: "sh", Profile: agents.Profile{Command: "sh", Args: []string{"-c", "exit 3"}}, Brief: "brief.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed.ID == ok.ID {
+		t.Fatalf("expected distinct run ids")
+	}
+	failed, err = Get(root, failed.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed.Status != StatusFailed || failed.ExitCode == nil || *failed.ExitCode != 3 {
+		t.Fatalf("unexpected run: %+v", failed)
+	}
+	all, err := List(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected 2 runs, got %d", len(all))
+	}
+}
+
+func TestLaunchMissingAgent(t *testing.T) {
+	root := t.TempDir()
+	superviseInProcess(t, false)
+	_, err := Launch(root, Request{Agent: "nope", Profile: agents.Profile{Command: "does-not-exist"}, Brief: "brief.md"})
+	if err == nil || !strings.Contains(err.Error(), "agent not found") {
+		t.Fatalf("expected agent not found, got %v", err)
+	}
+	if _, err := os.Stat(Dir(root)); !os.IsNotExist(err) {
+		t.Fatalf("expected no run record for missing agent")
+	}
+}
+
+func TestKillStopsRun(t *testing.T) {
+	root := t.TempDir()
+	superviseInProcess(t, true)
+	run, err := Launch(root, Request{Kind: "research", Agent: "sleep", Profile: agents.Profile{Command: "sleep"}, Brief: "30"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		if run, err = Get(root, run.ID); err == nil && run.Status == StatusRunning {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if run.Status != StatusRunning || run.PID == 0 {
+		t.Fatalf("expected running run, got %+v", run)
+	}
+	if _, err := Kill(root, run.ID); err != nil {
+		t.Fatal(err)
+	}
+	done, err := Wait(root, run.ID, 20*time.Millisecond, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if done.Status != StatusKilled {
+		t.Fatalf("expected killed, got %+v", done)
+	}
+	if _, err := Kill(root, run.ID); err == nil {
+		t.Fatalf("expected error killing finished run")
+	}
+}
+
+func TestKillEscalatesWhenTermIsIgnored(t *testing.T) {
+	root := t.TempDir()
+	superviseInProcess(t, true)
+	fastRetries(t)
+	profile := agents.Profile{Command: "sh", Args: []string{"-c", "trap '' TERM; sleep 30", "{brief}"}}
+	run, err := Launch(root, Request{Kind: "run", Agent: "sh", Profile: profile, Brief: "brief.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		if run, err = Get(root, run.ID); err == nil && run.Status == StatusRunning {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if run.Status != StatusRunning || run.PID == 0 {
+		t.Fatalf("expected running run, got %+v", run)
+	}
+	if _, err := Kill(root, run.ID); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 200 && alive(run.PID); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if alive(run.PID) {
+		t.Fatalf("expected agent ignoring SIGTERM to be killed")
+	}
+	if run, err = Get(root, run.ID); err != nil || run.Status != StatusKilled {
+		t.Fatalf("expected killed run, got %+v (%v)", run, err)
+	}
+}
+
+func TestLoadMarksDeadRunsLost(t *testing.T) {
+	root := t.TempDir()
+	cmd := exec.Command("true")
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+	run, err := Create(root, Run{Kind: "research", Agent: "codex"}, time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	run.Status = StatusRunning
+	run.PID = cmd.Process.Pid
+	run.SupervisorPID = cmd.Process.Pid
+	if err := Save(run); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(filepath.Join(Dir(root), run.ID+".yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Status != StatusLost {
+		t.Fatalf("expected lost, got %s", loaded.Status)
+	}
+	active, err := Active(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(active) != 0 {
+		t.Fatalf("expected no active runs")
+	}
+}
+
+func TestGetMatchesPrefix(t *testing.T) {
+	root := t.TempDir()
+	now := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	first, err := Create(root, Run{Agent: "codex"}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := Create(root, Run{Agent: "codex"}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.ID != "20260115-000000-codex" || second.ID != "20260115-000000-codex-2" {
+		t.Fatalf("unexpected ids %s %s", first.ID, second.ID)
+	}
+	if got, err := Get(root, first.ID); err != nil || got.ID != first.ID {
+		t.Fatalf("expected exact match, got %v %v", got.ID, err)
+	}
+	if got, err := Get(root, "20260115-000000-codex-"); err != nil || got.ID != second.ID {
+		t.Fatalf("expected prefix match, got %v %v", got.ID, err)
+	}
+	if _, err := Get(root, "2026"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("expected ambiguous error, got %v", err)
+	}
+}
+
+func TestSuperviseCapturesOutput(t *testing.T) {
+	root := t.TempDir()
+	superviseInProcess(t, false)
+	run, err := Launch(root, Request{Kind: "research", Agent: "sh", Profile: agents.Profile{Command: "sh", Args: []string{"-c", "echo out; echo err >&2; exit 1"}}, Brief: "brief.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	run, err = Get(root, run.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines, err := Tail(run, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(lines, ",") != "out,err" {
+		t.Fatalf("unexpected log lines %v", lines)
+	}
+	var buf strings.Builder
+	if err := Follow(root, run.ID, &buf, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "out\nerr\n" {
+		t.Fatalf("unexpected follow output %q", buf.String())
+	}
+}
+
+func TestFollowKeepsOutputAcrossRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.log")
+	w, err := openLog(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	tail := &logFollower{path: path}
+	defer tail.close()
+	var buf strings.Builder
+	for _, line := range []string{"one\n", "two\n", "three\n", "four\n", "five\n"} {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+		if line == "two\n" || line == "five\n" {
+			if err := tail.poll(&buf); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if buf.String() != "one\ntwo\nthree\nfour\nfive\n" {
+		t.Fatalf("expected every line once across rotations, got %q", buf.String())
+	}
+}
+
+func TestLogRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.log")
+	w, err := openLog(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"one\n", "two\n", "three\n", "four\n", "five\n", "six\n"} {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	files := LogFiles(Run{Log: path})
+	if len(files) != 3 || files[2] != path {
+		t.Fatalf("unexpected log files %v", files)
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Fatalf("expected at most 2 backups")
+	}
+	var all strings.Builder
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		all.Write(raw)
+	}
+	if all.String() != "three\nfour\nfive\nsix\n" {
+		t.Fatalf("unexpected rotated content %q", all.String())
+	}
+}
+
+func TestSuperviseIngestsChangedTarget(t *testing.T) {
+	root := t.TempDir()
+	superviseInProcess(t, false)
+	old := checkArtifact
+	t.Cleanup(func() { checkArtifact = old })
+	var checked []string
+	checkArtifact = func(root, kind, specID, artifact string) (ingest.Report, error) {
+		raw, _ := os.ReadFile(artifact)
+		checked = append(checked, string(raw))
+		return ingest.Report{Kind: kind, SpecID: specID, Artifact: artifact, Status: ingest.StatusReady, Summary: "1 suggested change"}, nil
+	}
+	target := filepath.Join(root, "PRD-001-suggestions.md")
+	if err := os.WriteFile(target, []byte("template\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	untouched, err := Launch(root, Request{Kind: "suggest", SpecID: "PRD-001", Agent: "sh", Profile: agents.Profile{Command: "true"}, Brief: "brief.md", Target: target})
+	if err != nil {
+		t.Fatal(err)
+	}
+	untouched, err = Get(root, untouched.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if untouched.Ingest == nil || untouched.Ingest.Status != ingest.StatusEmpty || len(checked) != 0 {
+		t.Fatalf("expected untouched template to be reported empty, got %+v", untouched.Ingest)
+	}
+	run, err := Launch(root, Request{Kind: "suggest", SpecID: "PRD-001", Agent: "sh", Profile: agents.Profile{Command: "sh", Args: []string{"-c", "echo filled > " + target}}, Brief: "brief.md", Target: target})
+	if err != nil {
+		t.Fatal(err)
+	}
+	run, err = Get(root, run.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Ingest == nil || !run.Ingest.Ready() || run.IngestHash == run.TargetHash {
+		t.Fatalf("expected ready ingest, got %+v", run)
+	}
+	if strings.Join(checked, "") != "filled\n" {
+		t.Fatalf("unexpected checks %q", checked)
+	}
+}
+
+func TestWatchTargetIngestsBeforeExit(t *testing.T) {
+	root := t.TempDir()
+	superviseInProcess(t, true)
+	oldPoll, oldCheck := targetPoll, checkArtifact
+	t.Cleanup(func() { targetPoll, checkArtifact = oldPoll, oldCheck })
+	targetPoll = 10 * time.Millisecond
+	checkArtifact = func(root, kind, specID, artifact string) (ingest.Report, error) {
+		return ingest.Report{Kind: kind, SpecID: specID, Artifact: artifact, Status: ingest.StatusReady}, nil
+	}
+	target := filepath.Join(root, "research.md")
+	if err := os.WriteFile(target, []byte("template\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run, err := Launch(root, Request{Kind: "research", SpecID: "PRD-001", Agent: "sh", Profile: agents.Profile{Command: "sh", Args: []string{"-c", "echo done > " + target + "; sleep 5"}}, Brief: "brief.md", Target: target})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		run, err = Get(root, run.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if run.Ingest != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected ingest while the agent is still running")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !run.Active() || !run.Ingest.Ready() {
+		t.Fatalf("unexpected run %+v", run)
+	}
+	if _, err := Kill(root, run.ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIngestDoesNotOverwriteKill(t *testing.T) {
+	root := t.TempDir()
+	oldCheck := checkArtifact
+	t.Cleanup(func() { checkArtifact = oldCheck })
+	checking, release := make(chan struct{}), make(chan struct{})
+	checkArtifact = func(root, kind, specID, artifact string) (ingest.Report, error) {
+		close(checking)
+		<-release
+		return ingest.Report{Kind: kind, SpecID: specID, Artifact: artifact, Status: ingest.StatusReady}, nil
+	}
+	target := filepath.Join(root, "research.md")
+	if err := os.WriteFile(target, []byte("done\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run, err := Create(root, Run{Kind: "research", SpecID: "PRD-001", Agent: "sh", Root: root, Target: target}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	run.Status = StatusRunning
+	run.SupervisorPID = os.Getpid()
+	if err := Save(run); err != nil {
+		t.Fatal(err)
+	}
+	ingested := make(chan error, 1)
+	go func() { ingested <- ingestActive(run, time.Now()) }()
+	<-checking
+	killed := make(chan error, 1)
+	go func() {
+		_, err := Kill(root, run.ID)
+		killed <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	if err := <-ingested; err != nil {
+		t.Fatal(err)
+	}
+	if err := <-killed; err != nil {
+		t.Fatal(err)
+	}
+	if run, err = Get(root, run.ID); err != nil || run.Status != StatusKilled || run.Ingest == nil {
+		t.Fatalf("expected killed run with its ingest report, got %+v (%v)", run, err)
+	}
+}
+
+func TestLaunchExpandsProfilePlaceholders(t *testing.T) {
+	root := t.TempDir()
+	superviseInProcess(t, false)
+	profile := agents.Profile{Command: "true", Args: []string{"--spec", "{spec}", "--out={output}"}, Env: map[string]string{"PRAUDE_ID": "{id}"}, Subagent: true}
+	run, err := Launch(root, Request{Kind: "research", SpecID: "PRD-001", Agent: "sh", Profile: profile, Brief: "brief.md", Target: "out.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := filepath.Join(root, ".praude", "specs", "PRD-001.yaml")
+	if got := strings.Join(run.Command, " "); got != "true --spec "+spec+" --out=out.md" {
+		t.Fatalf("unexpected command %q", got)
+	}
+	if got := strings.Join(run.Env, ","); got != "PRAUDE_ID=PRD-001,"+agents.SubagentEnv {
+		t.Fatalf("unexpected env %q", got)
+	}
+}
+
+func TestLaunchDeliversBriefOnStdinInWorkdir(t *testing.T) {
+	root := t.TempDir()
+	superviseInProcess(t, false)
+	brief := filepath.Join(root, "brief.md")
+	if err := os.WriteFile(brief, []byte("from stdin\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "work"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	profile := agents.Profile{Command: "sh", Args: []string{"-c", "cat; pwd"}, Brief: agents.BriefStdin, Workdir: "work"}
+	run, err := Launch(root, Request{Kind: "run", Agent: "sh", Profile: profile, Brief: brief})
+	if err != nil {
+		t.Fatal(err)
+	}
+	run, err = Get(root, run.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines, err := Tail(run, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 2 || lines[0] != "from stdin" || filepath.Base(lines[1]) != "work" {
+		t.Fatalf("unexpected output %v", lines)
+	}
+}
+
+func TestLaunchAttachesInteractiveRuns(t *testing.T) {
+	root := t.TempDir()
+	superviseInProcess(t, false)
+	old := attachSupervisor
+	t.Cleanup(func() { attachSupervisor = old })
+	attached := false
+	attachSupervisor = func(root, id string) error {
+		attached = true
+		return Supervise(root, id)
+	}
+	run, err := Launch(root, Request{Kind: "run", Agent: "sh", Profile: agents.Profile{Command: "true", Mode: agents.ModeInteractive}, Brief: "brief.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !attached || !run.Interactive || run.Status != StatusSucceeded {
+		t.Fatalf("expected finished interactive run, got %+v", run)
+	}
+}
+
+func fastRetries(t *testing.T) {
+	oldBackoff, oldGrace, oldPoll := retryBackoff, killGrace, queuePoll
+	t.Cleanup(func() { retryBackoff, killGrace, queuePoll = oldBackoff, oldGrace, oldPoll })
+	retryBackoff = func(int) time.Duration { return 0 }
+	killGrace = 100 * time.Millisecond
+	queuePoll = 10 * time.Millisecond
+}
+
+func TestSuperviseTimesOutAndRetries(t *testing.T) {
+	root := t.TempDir()
+	superviseInProcess(t, false)
+	fastRetries(t)
+	profile := agents.Profile{Command: "sleep", Timeout: 50 * time.Millisecond, MaxRetries: 1}
+	start := time.Now()
+	run, err := Launch(root, Request{Kind: "run", Agent: "sleep", Profile: profile, Brief: "5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(start) > 3*time.Second {
+		t.Fatalf("expected the hung agent to be killed")
+	}
+	run, err = Get(root, run.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Status != StatusFailed || run.Attempt != 2 || run.Error != "timed out after 50ms" {
+		t.Fatalf("unexpected run %+v", run)
+	}
+	raw, err := os.ReadFile(run.Log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw), "praude: attempt 2 of 2") {
+		t.Fatalf("expected retry marker in log, got %q", raw)
+	}
+}
+
+func TestSuperviseRetriesUntilSuccess(t *testing.T) {
+	root := t.TempDir()
+	superviseInProcess(t, false)
+	fastRetries(t)
+	profile := agents.Profile{Command: "sh", Args: []string{"-c", "test -f marker || { touch marker; exit 1; }"}, MaxRetries: 2}
+	run, err := Launch(root, Request{Kind: "run", Agent: "sh", Profile: profile, Brief: "brief.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	run, err = Get(root, run.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Status != StatusSucceeded || run.Attempt != 2 {
+		t.Fatalf("unexpected run %+v", run)
+	}
+}
+
+func TestSuperviseResetsSandboxBetweenAttempts(t *testing.T) {
+	root := t.TempDir()
+	superviseInProcess(t, false)
+	fastRetries(t)
+	if err := os.MkdirAll(filepath.Join(root, ".praude"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	brief := filepath.Join(root, ".praude", "brief.md")
+	if err := os.WriteFile(brief, []byte("brief\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	marker := filepath.Join(t.TempDir(), "marker")
+	script := "test ! -f leftover.txt || exit 3; test -f " + marker + " || { touch " + marker + " leftover.txt; exit 1; }"
+	profile := agents.Profile{Command: "sh", Args: []string{"-c", script}, Sandbox: agents.SandboxCopy, MaxRetries: 1}
+	run, err := Launch(root, Request{Kind: "run", Agent: "sh", Profile: profile, Brief: brief})
+	if err != nil {
+		t.Fatal(err)
+	}
+	run, err = Get(root, run.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Status != StatusSucceeded || run.Attempt != 2 {
+		t.Fatalf("expected retry in a fresh sandbox, got %+v", run)
+	}
+	if _, err := os.Stat(run.SandboxDir); !os.IsNotExist(err) {
+		t.Fatalf("expected sandbox removed, got %v", err)
+	}
+}
+
+func TestSuperviseQueuesBeyondConcurrencyLimit(t *testing.T) {
+	root := t.TempDir()
+	superviseInProcess(t, true)
+	fastRetries(t)
+	if err := os.MkdirAll(filepath.Join(root, ".praude"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".praude", "config.toml"), []byte("[runs]\nmax_concurrent = 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	blocker, err := Create(root, Run{Kind: "run", Agent: "other"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocker.Status = StatusRunning
+	blocker.SupervisorPID = os.Getpid()
+	if err := Save(blocker); err != nil {
+		t.Fatal(err)
+	}
+	run, err := Launch(root, Request{Kind: "run", Agent: "sh", Profile: agents.Profile{Command: "true"}, Brief: "brief.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		if run, err = Get(root, run.ID); err == nil && run.Status == StatusQueued {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if run.Status != StatusQueued {
+		t.Fatalf("expected run to wait for a slot, got %+v", run)
+	}
+	blocker.Status = StatusSucceeded
+	if err := Save(blocker); err != nil {
+		t.Fatal(err)
+	}
+	done, err := Wait(root, run.ID, 10*time.Millisecond, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if done.Status != StatusSucceeded {
+		t.Fatalf("expected queued run to finish, got %+v", done)
+	}
+}
+
+func TestKillQueuedRunRemovesSandbox(t *testing.T) {
+	root := t.TempDir()
+	superviseInProcess(t, true)
+	fastRetries(t)
+	if err := os.MkdirAll(filepath.Join(root, ".praude"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".praude", "config.toml"), []byte("[runs]\nmax_concurrent = 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	blocker, err := Create(root, Run{Kind: "run", Agent: "other"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocker.Status = StatusRunning
+	blocker.SupervisorPID = os.Getpid()
+	if err := Save(blocker); err != nil {
+		t.Fatal(err)
+	}
+	brief := filepath.Join(root, ".praude", "brief.md")
+	if err := os.WriteFile(brief, []byte("brief\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	profile := agents.Profile{Command: "true", Sandbox: agents.SandboxCopy}
+	run, err := Launch(root, Request{Kind: "run", Agent: "sh", Profile: profile, Brief: brief})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		if run, err = Get(root, run.ID); err == nil && run.Status == StatusQueued {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if run.Status != StatusQueued || run.SandboxDir == "" {
+		t.Fatalf("expected sandboxed run to wait for a slot, got %+v", run)
+	}
+	if _, err := Kill(root, run.ID); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 200; i++ {
+		if _, err := os.Stat(run.SandboxDir); os.IsNotExist(err) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, err := os.Stat(run.SandboxDir); !os.IsNotExist(err) {
+		t.Fatalf("expected sandbox removed after kill, got %v", err)
+	}
+	if run, err = Get(root, run.ID); err != nil || run.Status != StatusKilled {
+		t.Fatalf("expected killed run, got %+v (%v)", run, err)
+	}
+}
+
+func TestSandboxImportsOnlyTheArtifact(t *testing.T) {
+	for _, mode := range []string{agents.SandboxCopy, agents.SandboxWorktree} {
+		t.Run(mode, func(t *testing.T) {
+			root := t.TempDir()
+			superviseInProcess(t, false)
+			old := checkArtifact
+			t.Cleanup(func() { checkArtifact = old })
+			checkArtifact = func(root, kind, specID, artifact string) (ingest.Report, error) {
+				return ingest.Report{Kind: kind, SpecID: specID, Artifact: artifact, Status: ingest.StatusReady}, nil
+			}
+			if err := os.MkdirAll(filepath.Join(root, ".praude", "suggestions"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(root, "keep.txt"), []byte("keep\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if mode == agents.SandboxWorktree {
+				if _, err := exec.LookPath("git"); err != nil {
+					t.Skip("git not installed")
+				}
+				for _, args := range [][]string{
+					{"init", "-q"},
+					{"add", "keep.txt"},
+					{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "init"},
+				} {
+					if out, err := exec.Command("git", append([]string{"-C", root}, args...)...).CombinedOutput(); err != nil {
+						t.Fatalf("git %v: %v\n%s", args, err, out)
+					}
+				}
+			}
+			target := filepath.Join(root, ".praude", "suggestions", "PRD-001-20260115-100000.md")
+			if err := os.WriteFile(target, []byte("template\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			brief := filepath.Join(root, ".praude", "brief.md")
+			if err := os.WriteFile(brief, []byte("Write into "+target+"\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			script := `grep -q "$PWD" {brief} && echo filled > {output} && echo rogue > rogue.txt && rm keep.txt`
+			profile := agents.Profile{Command: "sh", Args: []string{"-c", script}, Sandbox: mode}
+			run, err := Launch(root, Request{Kind: "suggest", SpecID: "PRD-001", Agent: "sh", Profile: profile, Brief: brief, Target: target})
+			if err != nil {
+				t.Fatal(err)
+			}
+			run, err = Get(root, run.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if run.Status != StatusSucceeded {
+				t.Fatalf("expected success, got %+v", run)
+			}
+			if raw, _ := os.ReadFile(target); string(raw) != "filled\n" {
+				t.Fatalf("expected artifact imported, got %q", raw)
+			}
+			if _, err := os.Stat(filepath.Join(root, "rogue.txt")); err == nil {
+				t.Fatalf("expected rogue file discarded")
+			}
+			if _, err := os.Stat(filepath.Join(root, "keep.txt")); err != nil {
+				t.Fatalf("expected deleted file restored: %v", err)
+			}
+			if strings.Join(run.Discarded, ",") != "D keep.txt,A rogue.txt" {
+				t.Fatalf("unexpected discarded changes %v", run.Discarded)
+			}
+			if _, err := os.Stat(run.SandboxDir); !os.IsNotExist(err) {
+				t.Fatalf("expected sandbox removed, got %v", err)
+			}
+			if raw, _ := os.ReadFile(run.Log); !strings.Contains(string(raw), "discarded 2 change(s)") {
+				t.Fatalf("expected discard note in log, got %q", raw)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package runs
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mistakeknot/praude/internal/agents"
+	"github.com/mistakeknot/praude/internal/git"
+	"github.com/mistakeknot/praude/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	sandboxTree     = "tree"
+	sandboxManifest = "manifest.yaml"
+)
+
+func sandboxTreeDir(base string) string {
+	return filepath.Join(base, sandboxTree)
+}
+
+func createSandbox(root, mode string) (string, error) {
+	base, err := os.MkdirTemp("", "praude-sandbox-")
+	if err != nil {
+		return "", err
+	}
+	if err := populateSandbox(root, mode, base); err != nil {
+		removeSandbox(root, mode, base)
+		return "", err
+	}
+	return base, nil
+}
+
+func populateSandbox(root, mode, base string) error {
+	var err error
+	tree := sandboxTreeDir(base)
+	switch mode {
+	case agents.SandboxWorktree:
+		if err = git.AddWorktree(root, tree); err == nil {
+			err = copyTree(project.RootDir(root), filepath.Join(tree, project.PraudeDir), project.RunsDir(root))
+		}
+	default:
+		err = copyTree(root, tree, project.RunsDir(root))
+	}
+	if err != nil {
+		return fmt.Errorf("sandbox %s: %w", mode, err)
+	}
+	return nil
+}
+
+func resetSandbox(run Run) error {
+	tree := sandboxTreeDir(run.SandboxDir)
+	if run.Sandbox == agents.SandboxWorktree {
+		_ = git.RemoveWorktree(run.Root, tree)
+	}
+	if err := os.RemoveAll(tree); err != nil {
+		return err
+	}
+	if err := populateSandbox(run.Root, run.Sandbox, run.SandboxDir); err != nil {
+		return err
+	}
+	if _, err := sandboxBrief(run.Root, run.SandboxDir, run.Brief); err != nil {
+		return err
+	}
+	return sealSandbox(run.SandboxDir)
+}
+
+func sealSandbox(base string) error {
+	manifest, err := snapshot(sandboxTreeDir(base))
+	if err != nil {
+		return err
+	}
+	raw, err := yaml.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(base, sandboxManifest), raw, 0o644)
+}
+
+func removeSandbox(root, mode, base string) {
+	if mode == agents.SandboxWorktree {
+		_ = git.RemoveWorktree(root, sandboxTreeDir(base))
+	}
+	_ = os.RemoveAll(base)
+}
+
+func sandboxPath(root, base, path string) (string, error) {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the project", path)
+	}
+	return filepath.Join(sandboxTreeDir(base), rel), nil
+}
+
+func sandboxBrief(root, base, brief string) (string, error) {
+	raw, err := os.ReadFile(brief)
+	if err != nil {
+		return "", err
+	}
+	path, err := sandboxPath(root, base, brief)
+	if err != nil {
+		path = filepath.Join(sandboxTreeDir(base), project.PraudeDir, "briefs", filepath.Base(brief))
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	content := strings.ReplaceAll(string(raw), root, sandboxTreeDir(base))
+	return path, os.WriteFile(path, []byte(content), 0o644)
+}
+
+func closeSandbox(run *Run) error {
+	defer removeSandbox(run.Root, run.Sandbox, run.SandboxDir)
+	changes, err := sandboxChanges(run.SandboxDir)
+	if err != nil {
+		return err
+	}
+	artifact := ""
+	if run.Target != "" {
+		artifact, _ = filepath.Rel(run.Root, run.Target)
+	}
+	run.Discarded = nil
+	for _, change := range changes {
+		rel := change[2:]
+		if rel == artifact && change[0] != 'D' && run.Status != StatusKilled {
+			if err := copyFile(filepath.Join(sandboxTreeDir(run.SandboxDir), rel), run.Target); err != nil {
+				return fmt.Errorf("import %s: %w", rel, err)
+			}
+			continue
+		}
+		run.Discarded = append(run.Discarded, change)
+	}
+	if len(run.Discarded) > 0 && run.Log != "" {
+		if log, err := os.OpenFile(run.Log, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644); err == nil {
+			fmt.Fprintf(log, "praude: discarded %d change(s) outside the artifact: %s\n", len(run.Discarded), strings.Join(run.Discarded, ", "))
+			log.Close()
+		}
+	}
+	return nil
+}
+
+func sandboxChanges(base string) ([]string, error) {
+	raw, err := os.ReadFile(filepath.Join(base, sandboxManifest))
+	if err != nil {
+		return nil, err
+	}
+	before := map[string]string{}
+	if err := yaml.Unmarshal(raw, &before); err != nil {
+		return nil, err
+	}
+	after, err := snapshot(sandboxTreeDir(base))
+	if err != nil {
+		return nil, err
+	}
+	var changes []string
+	for path, hash := range after {
+		switch old, ok := before[path]; {
+		case !ok:
+			changes = append(changes, "A "+path)
+		case old != hash:
+			changes = append(changes, "M "+path)
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			changes = append(changes, "D "+path)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i][2:] < changes[j][2:] })
+	return changes, nil
+}
+
+func snapshot(tree string) (map[string]string, error) {
+	out := map[string]string{}
+	err := filepath.WalkDir(tree, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Name() == ".git" {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(tree, path)
+		if err != nil {
+			return err
+		}
+		if entry.Type()&fs.ModeSymlink != 0 {
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			out[rel] = "link:" + target
+			return nil
+		}
+		out[rel] = fileHash(path)
+		return nil
+	})
+	return out, err
+}
+
+func copyTree(src, dst, skip string) error {
+	return filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == skip || entry.Name() == ".git" {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case entry.IsDir():
+			return os.MkdirAll(target, 0o755)
+		case entry.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			_ = os.Remove(target)
+			return os.Symlink(link, target)
+		case entry.Type().IsRegular():
+			return copyFile(path, target)
+		}
+		return nil
+	})
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,385 @@
+package runs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/agents"
+	"github.com/mistakeknot/praude/internal/project"
+)
+
+type Request struct {
+	Kind    string
+	SpecID  string
+	Agent   string
+	Profile agents.Profile
+	Brief   string
+	Target  string
+}
+
+var spawnSupervisor = func(root, id string) error {
+	cmd, err := SuperviseCommand(root, id)
+	if err != nil {
+		return err
+	}
+	detachSupervisor(cmd)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+var attachSupervisor = func(root, id string) error {
+	cmd, err := SuperviseCommand(root, id)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Run()
+}
+
+func SuperviseCommand(root, id string) (*exec.Cmd, error) {
+	self, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(self, "runs", "supervise", id)
+	cmd.Dir = root
+	return cmd, nil
+}
+
+func Launch(root string, req Request) (Run, error) {
+	run, err := Prepare(root, req)
+	if err != nil {
+		return run, err
+	}
+	start := spawnSupervisor
+	if run.Interactive {
+		start = attachSupervisor
+	}
+	if err := start(root, run.ID); err != nil {
+		if run.SandboxDir != "" {
+			removeSandbox(root, run.Sandbox, run.SandboxDir)
+		}
+		run.Status = StatusFailed
+		run.Error = err.Error()
+		run.EndedAt = time.Now().UTC().Format(time.RFC3339)
+		_ = Save(run)
+		return run, err
+	}
+	if run.Interactive {
+		return Get(root, run.ID)
+	}
+	return run, nil
+}
+
+func Prepare(root string, req Request) (Run, error) {
+	if err := agents.Available(req.Profile); err != nil {
+		return Run{}, fmt.Errorf("agent not found: %w", err)
+	}
+	vars := agents.Vars{Brief: req.Brief, ID: req.SpecID, Output: req.Target, Root: root}
+	if req.SpecID != "" {
+		vars.Spec = filepath.Join(project.SpecsDir(root), req.SpecID+".yaml")
+	}
+	var sandbox string
+	if req.Profile.Sandbox != "" {
+		var err error
+		if sandbox, err = createSandbox(root, req.Profile.Sandbox); err != nil {
+			return Run{}, err
+		}
+		if vars, err = sandboxVars(root, sandbox, vars); err == nil {
+			err = sealSandbox(sandbox)
+		}
+		if err != nil {
+			removeSandbox(root, req.Profile.Sandbox, sandbox)
+			return Run{}, err
+		}
+	}
+	run, err := newRun(root, req, vars)
+	if err == nil {
+		run.Sandbox, run.SandboxDir = req.Profile.Sandbox, sandbox
+		run, err = Create(root, run, time.Now())
+	}
+	if err != nil && sandbox != "" {
+		removeSandbox(root, req.Profile.Sandbox, sandbox)
+	}
+	return run, err
+}
+
+func newRun(root string, req Request, vars agents.Vars) (Run, error) {
+	command, err := agents.CommandLine(req.Profile, vars)
+	if err != nil {
+		return Run{}, err
+	}
+	env, err := agents.Environ(req.Profile, vars)
+	if err != nil {
+		return Run{}, err
+	}
+	dir, err := agents.Workdir(req.Profile, vars)
+	if err != nil {
+		return Run{}, err
+	}
+	run := Run{
+		Kind:        req.Kind,
+		SpecID:      req.SpecID,
+		Agent:       req.Agent,
+		Brief:       req.Brief,
+		Target:      req.Target,
+		Command:     command,
+		Env:         env,
+		Root:        root,
+		Dir:         dir,
+		Interactive: req.Profile.Interactive(),
+		MaxRetries:  req.Profile.MaxRetries,
+	}
+	if req.Profile.Timeout > 0 {
+		run.Timeout = req.Profile.Timeout.String()
+	}
+	if req.Profile.BriefDelivery() == agents.BriefStdin {
+		run.Stdin = vars.Brief
+	}
+	if req.Target != "" {
+		run.TargetHash = fileHash(req.Target)
+	}
+	return run, nil
+}
+
+func sandboxVars(root, sandbox string, vars agents.Vars) (agents.Vars, error) {
+	var err error
+	out := vars
+	out.Root = sandboxTreeDir(sandbox)
+	if out.Brief, err = sandboxBrief(root, sandbox, vars.Brief); err != nil {
+		return vars, err
+	}
+	if vars.Output != "" {
+		if out.Output, err = sandboxPath(root, sandbox, vars.Output); err != nil {
+			return vars, err
+		}
+	}
+	if vars.Spec != "" {
+		if out.Spec, err = sandboxPath(root, sandbox, vars.Spec); err != nil {
+			return vars, err
+		}
+	}
+	return out, nil
+}
+
+func Supervise(root, id string) error {
+	run, err := Get(root, id)
+	if err != nil {
+		return err
+	}
+	if len(run.Command) == 0 {
+		return finish(run, errors.New("run has no command"))
+	}
+	run.SupervisorPID = os.Getpid()
+	var log *rotatingWriter
+	if run.Log != "" && !run.Interactive {
+		if log, err = openLog(run.Log, MaxLogBytes, LogBackups); err != nil {
+			return finish(run, err)
+		}
+		defer log.Close()
+	}
+	timeout, _ := time.ParseDuration(run.Timeout)
+	for attempt := 1; ; attempt++ {
+		if !run.Interactive {
+			ok, err := acquireSlot(root, &run)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return finish(run, nil)
+			}
+		}
+		run.Attempt = attempt
+		if attempt > 1 && log != nil {
+			fmt.Fprintf(log, "praude: attempt %d of %d\n", attempt, run.MaxRetries+1)
+		}
+		waitErr := runAttempt(&run, log, timeout)
+		if waitErr == nil || run.Interactive || attempt > run.MaxRetries || killed(run) {
+			return finish(run, waitErr)
+		}
+		if run.SandboxDir != "" {
+			if err := resetSandbox(run); err != nil {
+				return finish(run, fmt.Errorf("reset sandbox: %w", err))
+			}
+		}
+		run.Status = StatusQueued
+		run.PID = 0
+		run.Error = waitErr.Error()
+		if err := Save(run); err != nil {
+			return err
+		}
+		if !sleepUnlessKilled(run, retryBackoff(attempt)) {
+			return finish(run, nil)
+		}
+	}
+}
+
+func runAttempt(run *Run, log *rotatingWriter, timeout time.Duration) error {
+	cmd := exec.Command(run.Command[0], run.Command[1:]...)
+	cmd.Dir = run.Dir
+	if len(run.Env) > 0 {
+		cmd.Env = append(os.Environ(), run.Env...)
+	}
+	if run.Stdin != "" {
+		in, err := os.Open(run.Stdin)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		cmd.Stdin = in
+	}
+	if run.Interactive {
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	} else {
+		detach(cmd)
+	}
+	if log != nil {
+		cmd.Stdout, cmd.Stderr = log, log
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	run.PID = cmd.Process.Pid
+	run.Status = StatusRunning
+	if err := Save(*run); err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return err
+	}
+	stop, watched := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(watched)
+		watchTarget(*run, stop)
+	}()
+	defer func() {
+		close(stop)
+		<-watched
+	}()
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	poll := time.NewTicker(queuePoll)
+	defer poll.Stop()
+	for {
+		select {
+		case err := <-done:
+			return err
+		case <-expired:
+			stopAgent(*run, done)
+			return fmt.Errorf("timed out after %s", timeout)
+		case <-poll.C:
+			if killed(*run) {
+				stopAgent(*run, done)
+				return errors.New("killed")
+			}
+		}
+	}
+}
+
+func stopAgent(run Run, done <-chan error) {
+	terminate(run, false)
+	select {
+	case <-done:
+	case <-time.After(killGrace):
+		terminate(run, true)
+		<-done
+	}
+}
+
+func killed(run Run) bool {
+	latest, err := Load(run.Path)
+	return err == nil && latest.Status == StatusKilled
+}
+
+func finish(run Run, waitErr error) error {
+	unlock, err := lockRun(run)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	latest, err := Load(run.Path)
+	if err == nil {
+		run.Ingest, run.IngestHash, run.IngestedAt = latest.Ingest, latest.IngestHash, latest.IngestedAt
+	}
+	if err == nil && latest.Status == StatusKilled {
+		run.Status = StatusKilled
+	} else if waitErr != nil {
+		run.Status = StatusFailed
+		run.Error = waitErr.Error()
+	} else {
+		run.Status = StatusSucceeded
+	}
+	var exitErr *exec.ExitError
+	switch {
+	case waitErr == nil:
+		code := 0
+		run.ExitCode = &code
+	case errors.As(waitErr, &exitErr):
+		code := exitErr.ExitCode()
+		run.ExitCode = &code
+	}
+	if run.SandboxDir != "" {
+		if err := closeSandbox(&run); err != nil && run.Status == StatusSucceeded {
+			run.Status = StatusFailed
+			run.Error = err.Error()
+		}
+	}
+	run.EndedAt = time.Now().UTC().Format(time.RFC3339)
+	if run.Status != StatusKilled {
+		ingestTarget(&run, time.Now())
+	}
+	return Save(run)
+}
+
+func Kill(root, id string) (Run, error) {
+	run, err := markKilled(root, id)
+	if err != nil {
+		return run, err
+	}
+	terminate(run, false)
+	return run, nil
+}
+
+func markKilled(root, id string) (Run, error) {
+	unlock, err := lockQueue(root)
+	if err != nil {
+		return Run{}, err
+	}
+	defer unlock()
+	run, err := Get(root, id)
+	if err != nil {
+		return run, err
+	}
+	if !run.Active() {
+		return run, fmt.Errorf("run %s is not active (%s)", run.ID, run.Status)
+	}
+	run.Status = StatusKilled
+	run.EndedAt = time.Now().UTC().Format(time.RFC3339)
+	return run, Save(run)
+}
+
+func Wait(root, id string, poll, timeout time.Duration) (Run, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		run, err := Get(root, id)
+		if err != nil || !run.Active() {
+			return run, err
+		}
+		if timeout > 0 && time.Now().After(deadline) {
+			return run, fmt.Errorf("timed out waiting for run %s", run.ID)
+		}
+		time.Sleep(poll)
+	}
+}
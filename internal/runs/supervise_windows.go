@@ -0,0 +1,42 @@
+//go:build windows
+
+package runs
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+const (
+	processQueryLimitedInformation = 0x1000
+	stillActive                    = 259
+)
+
+func detach(cmd *exec.Cmd) {}
+
+func detachSupervisor(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+func terminate(run Run, force bool) {
+	if run.PID == 0 {
+		return
+	}
+	if proc, err := os.FindProcess(run.PID); err == nil {
+		_ = proc.Kill()
+	}
+}
+
+func alive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	handle, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+	defer syscall.CloseHandle(handle)
+	var code uint32
+	return syscall.GetExitCodeProcess(handle, &code) == nil && code == stillActive
+}
@@ -0,0 +1,150 @@
+package scan
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	ReasonFileToModify = "file to modify"
+	ReasonReadme       = "readme"
+	ReasonManifest     = "manifest"
+	ReasonKeywords     = "matches"
+)
+
+var manifests = map[string]bool{
+	"go.mod":           true,
+	"package.json":     true,
+	"cargo.toml":       true,
+	"pyproject.toml":   true,
+	"requirements.txt": true,
+	"gemfile":          true,
+	"pom.xml":          true,
+	"build.gradle":     true,
+	"composer.json":    true,
+	"makefile":         true,
+}
+
+var stopwords = map[string]bool{
+	"about": true, "after": true, "allow": true, "allows": true, "also": true, "should": true,
+	"their": true, "there": true, "these": true, "this": true, "that": true, "they": true,
+	"when": true, "where": true, "which": true, "while": true, "will": true, "with": true,
+	"without": true, "must": true, "from": true, "into": true, "have": true, "each": true,
+	"every": true, "only": true, "than": true, "then": true, "user": true, "users": true,
+	"able": true, "make": true, "more": true, "most": true, "other": true, "some": true,
+	"such": true, "support": true, "supports": true, "them": true, "what": true, "your": true,
+}
+
+type Hints struct {
+	Files    []string
+	Keywords []string
+}
+
+type Ranked struct {
+	Entry  Entry
+	Score  int
+	Reason string
+}
+
+type Excerpt struct {
+	Path      string
+	Reason    string
+	Content   string
+	Truncated bool
+}
+
+func Keywords(texts []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, text := range texts {
+		words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+		})
+		for _, word := range words {
+			if len(word) < 4 || stopwords[word] || seen[word] || strings.IndexFunc(word, unicode.IsLetter) < 0 {
+				continue
+			}
+			seen[word] = true
+			out = append(out, word)
+		}
+	}
+	return out
+}
+
+func Rank(res Result, hints Hints) []Ranked {
+	modify := make(map[string]bool)
+	for _, file := range hints.Files {
+		modify[cleanPath(file)] = true
+	}
+	var out []Ranked
+	for _, entry := range res.Entries {
+		base := strings.ToLower(path.Base(entry.Path))
+		ranked := Ranked{Entry: entry}
+		switch {
+		case modify[entry.Path]:
+			ranked.Score, ranked.Reason = 1000, ReasonFileToModify
+		case strings.HasPrefix(base, "readme") && !strings.Contains(entry.Path, "/"):
+			ranked.Score, ranked.Reason = 500, ReasonReadme
+		case manifests[base] && !strings.Contains(entry.Path, "/"):
+			ranked.Score, ranked.Reason = 250, ReasonManifest
+		default:
+			var matched []string
+			lowerPath, lowerContent := strings.ToLower(entry.Path), strings.ToLower(entry.Content)
+			for _, keyword := range hints.Keywords {
+				switch {
+				case strings.Contains(lowerPath, keyword):
+					ranked.Score += 3
+				case strings.Contains(lowerContent, keyword):
+					ranked.Score++
+				default:
+					continue
+				}
+				matched = append(matched, keyword)
+			}
+			ranked.Reason = ReasonKeywords + " " + strings.Join(matched, ", ")
+		}
+		if ranked.Score > 0 {
+			out = append(out, ranked)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Entry.Path < out[j].Entry.Path
+	})
+	return out
+}
+
+func Excerpts(res Result, hints Hints, budget int) []Excerpt {
+	var out []Excerpt
+	remaining := budget
+	for _, ranked := range Rank(res, hints) {
+		if remaining <= 0 {
+			break
+		}
+		content := ranked.Entry.Content
+		excerpt := Excerpt{Path: ranked.Entry.Path, Reason: ranked.Reason}
+		if len(content) > remaining {
+			content = truncate(content, remaining)
+			excerpt.Truncated = true
+		}
+		if strings.TrimSpace(content) == "" {
+			continue
+		}
+		excerpt.Content = content
+		remaining -= len(content)
+		out = append(out, excerpt)
+	}
+	return out
+}
+
+func truncate(content string, limit int) string {
+	cut := content[:limit]
+	if idx := strings.LastIndex(cut, "\n"); idx > 0 {
+		return cut[:idx+1]
+	}
+	return ""
+}
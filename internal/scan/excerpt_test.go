@@ -0,0 +1,41 @@
+package scan
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKeywordsDropShortWordsStopwordsAndIDs(t *testing.T) {
+	got := strings.Join(Keywords([]string{"REQ-001: Export invoices to CSV", "Users should export invoices monthly"}), ",")
+	if got != "export,invoices,monthly" {
+		t.Fatalf("unexpected keywords %q", got)
+	}
+}
+
+func TestExcerptsRankAndRespectBudget(t *testing.T) {
+	res := Result{Entries: []Entry{
+		{Path: "internal/billing/export.go", Content: "package billing\n"},
+		{Path: "docs/notes.md", Content: "nothing relevant\n"},
+		{Path: "go.mod", Content: "module example\n"},
+		{Path: "internal/ui/view.go", Content: "// renders invoices\n"},
+		{Path: "README.md", Content: "# Example\nline two\nline three\n"},
+		{Path: "cmd/main.go", Content: "package main\n"},
+	}}
+	hints := Hints{Files: []string{"./cmd/main.go"}, Keywords: []string{"export", "invoices"}}
+	ranked := Rank(res, hints)
+	var order []string
+	for _, item := range ranked {
+		order = append(order, item.Entry.Path)
+	}
+	want := "cmd/main.go,README.md,go.mod,internal/billing/export.go,internal/ui/view.go"
+	if strings.Join(order, ",") != want {
+		t.Fatalf("expected %s, got %s", want, strings.Join(order, ","))
+	}
+	if ranked[3].Reason != "matches export" {
+		t.Fatalf("unexpected reason %q", ranked[3].Reason)
+	}
+	excerpts := Excerpts(res, hints, len("package main\n")+len("# Example\nline two\n")+3)
+	if len(excerpts) != 2 || !excerpts[1].Truncated || excerpts[1].Content != "# Example\nline two\n" {
+		t.Fatalf("expected README truncated at a line break, got %+v", excerpts)
+	}
+}
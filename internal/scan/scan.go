@@ -31,14 +31,24 @@ type ignoreRule struct {
 	anchored bool
 }
 
-func ScanRepo(root string, opts Options) (Result, error) {
-	res := Result{}
-	if opts.MaxBytesTotal <= 0 {
-		opts.MaxBytesTotal = 2 * 1024 * 1024
+var secretPatterns = []string{
+	".env", ".env.*", "*.pem", "*.key", "*.p12", "*.pfx",
+	"id_rsa*", "id_ecdsa*", "id_ed25519*", ".netrc", ".npmrc", ".pypirc",
+}
+
+func (o Options) withDefaults() Options {
+	if o.MaxBytesTotal <= 0 {
+		o.MaxBytesTotal = 2 * 1024 * 1024
 	}
-	if opts.MaxBytesPerFile <= 0 {
-		opts.MaxBytesPerFile = 256 * 1024
+	if o.MaxBytesPerFile <= 0 {
+		o.MaxBytesPerFile = 256 * 1024
 	}
+	return o
+}
+
+func ScanRepo(root string, opts Options) (Result, error) {
+	res := Result{}
+	opts = opts.withDefaults()
 	rules, _ := loadGitignore(root)
 	walkErr := filepath.WalkDir(root, func(full string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -92,6 +102,35 @@ func ScanRepo(root string, opts Options) (Result, error) {
 	return res, walkErr
 }
 
+func Include(res Result, root string, paths []string, opts Options) Result {
+	opts = opts.withDefaults()
+	have := make(map[string]bool, len(res.Entries))
+	for _, entry := range res.Entries {
+		have[entry.Path] = true
+	}
+	out := res
+	out.Entries = nil
+	for _, file := range paths {
+		rel := cleanPath(file)
+		if have[rel] || rel == "." || rel == ".." || strings.HasPrefix(rel, "../") || path.IsAbs(rel) || isDefaultIgnored(rel) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(rel)))
+		if err != nil || int64(len(data)) > opts.MaxBytesPerFile || isBinary(data) {
+			continue
+		}
+		have[rel] = true
+		out.Entries = append(out.Entries, Entry{Path: rel, Size: int64(len(data)), Content: string(data)})
+		out.TotalBytes += int64(len(data))
+	}
+	out.Entries = append(out.Entries, res.Entries...)
+	return out
+}
+
+func cleanPath(file string) string {
+	return path.Clean(strings.TrimPrefix(filepath.ToSlash(file), "./"))
+}
+
 func loadGitignore(root string) ([]ignoreRule, error) {
 	path := filepath.Join(root, ".gitignore")
 	raw, err := os.ReadFile(path)
@@ -173,5 +212,11 @@ func isDefaultIgnored(rel string) bool {
 	if rel == ".praude" || strings.HasPrefix(rel, ".praude/") {
 		return true
 	}
+	base := path.Base(rel)
+	for _, pattern := range secretPatterns {
+		if matchPath(pattern, base) {
+			return true
+		}
+	}
 	return false
 }
@@ -93,6 +93,48 @@ func TestScanRepoRespectsTotalBytes(t *testing.T) {
 	}
 }
 
+func TestScanRepoSkipsSecrets(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "config"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{".env", ".env.local", "config/server.pem", "id_rsa", ".envrc", "main.go"} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	res, err := ScanRepo(root, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths := entryPaths(res.Entries)
+	if len(paths) != 2 || !paths["main.go"] || !paths[".envrc"] {
+		t.Fatalf("expected secret files to be skipped, got %v", paths)
+	}
+}
+
+func TestIncludeAddsListedFilesCutByTheTotal(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range map[string]string{"one.txt": "12345678", "two.txt": "ABCDEFGH", ".env": "SECRET=1"} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	res, err := ScanRepo(root, Options{MaxBytesTotal: 10, MaxBytesPerFile: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res = Include(res, root, []string{"./one.txt", "two.txt", ".env", "../outside.txt", "missing.txt"}, Options{})
+	paths := entryPaths(res.Entries)
+	if len(res.Entries) != 2 || !paths["one.txt"] || !paths["two.txt"] {
+		t.Fatalf("expected both listed files once and no secrets, got %v", res.Entries)
+	}
+	ranked := Rank(res, Hints{Files: []string{"two.txt"}})
+	if len(ranked) != 1 || ranked[0].Entry.Content != "ABCDEFGH" {
+		t.Fatalf("expected the listed file to rank first, got %+v", ranked)
+	}
+}
+
 func entryPaths(entries []Entry) map[string]bool {
 	out := make(map[string]bool)
 	for _, e := range entries {
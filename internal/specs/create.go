@@ -76,6 +76,21 @@ competitive_landscape:
       - path: ".praude/research/PRD-001-YYYYMMDD-HHMMSS.md"
         anchor: "section-2"
         note: "Source quote"
+oss_landscape:
+  - id: "OSS-001"
+    name: "Example project"
+    repo: "https://github.com/example/project"
+    license: "MIT"
+    learnings:
+      - "Key takeaway"
+    insights:
+      - "Product or UX note"
+    reusable_components:
+      - "Component worth reusing"
+    evidence_refs:
+      - path: ".praude/research/PRD-001-YYYYMMDD-HHMMSS.md"
+        anchor: "section-3"
+        note: "Source quote"
 research:
   - ".praude/research/PRD-001-YYYYMMDD-HHMMSS.md"
 complexity: "medium"
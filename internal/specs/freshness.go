@@ -0,0 +1,49 @@
+package specs
+
+import (
+	"strings"
+	"time"
+)
+
+const ClaimDateLayout = "2006-01-02"
+
+type StaleClaim struct {
+	SpecID  string
+	ID      string
+	Claim   string
+	Date    string
+	AgeDays int
+}
+
+func ParseClaimDate(value string) (time.Time, error) {
+	return time.Parse(ClaimDateLayout, strings.TrimSpace(value))
+}
+
+func StaleClaims(spec Spec, now time.Time, maxAgeDays int) []StaleClaim {
+	var out []StaleClaim
+	for _, item := range spec.MarketResearch {
+		claim := StaleClaim{SpecID: spec.ID, ID: item.ID, Claim: item.Claim, Date: item.Date, AgeDays: -1}
+		date, err := ParseClaimDate(item.Date)
+		if err != nil {
+			out = append(out, claim)
+			continue
+		}
+		if !isStale(date, now, maxAgeDays) {
+			continue
+		}
+		claim.AgeDays = ageDays(date, now)
+		out = append(out, claim)
+	}
+	return out
+}
+
+func isStale(date, now time.Time, maxAgeDays int) bool {
+	if maxAgeDays <= 0 {
+		return false
+	}
+	return ageDays(date, now) > maxAgeDays
+}
+
+func ageDays(date, now time.Time) int {
+	return int(now.Sub(date).Hours() / 24)
+}
@@ -0,0 +1,28 @@
+package specs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStaleClaimsReportsOldAndUndatedClaims(t *testing.T) {
+	spec := Spec{
+		ID: "PRD-001",
+		MarketResearch: []MarketResearchItem{
+			{ID: "MR-001", Claim: "Old", Date: "2025-01-01"},
+			{ID: "MR-002", Claim: "Fresh", Date: "2026-01-10"},
+			{ID: "MR-003", Claim: "Undated"},
+		},
+	}
+	now := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	stale := StaleClaims(spec, now, 90)
+	if len(stale) != 2 {
+		t.Fatalf("expected 2 stale claims, got %d", len(stale))
+	}
+	if stale[0].ID != "MR-001" || stale[0].AgeDays != 379 {
+		t.Fatalf("unexpected stale claim: %+v", stale[0])
+	}
+	if stale[1].ID != "MR-003" || stale[1].AgeDays != -1 {
+		t.Fatalf("expected undated claim, got %+v", stale[1])
+	}
+}
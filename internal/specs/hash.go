@@ -7,6 +7,8 @@ import (
 )
 
 type hashPayload struct {
+	Title                string                     `json:"title"`
+	StrategicContext     StrategicContext           `json:"strategic_context"`
 	StoryText            string                     `json:"story_text"`
 	Summary              string                     `json:"summary"`
 	Requirements         []string                   `json:"requirements"`
@@ -15,6 +17,10 @@ type hashPayload struct {
 	CriticalUserJourneys []CriticalUserJourney      `json:"critical_user_journeys"`
 	MarketResearch       []MarketResearchItem       `json:"market_research"`
 	CompetitiveLandscape []CompetitiveLandscapeItem `json:"competitive_landscape"`
+	OSSLandscape         []OSSLandscapeItem         `json:"oss_landscape"`
+	Complexity           string                     `json:"complexity"`
+	EstimatedMinutes     int                        `json:"estimated_minutes"`
+	Priority             int                        `json:"priority"`
 }
 
 func StoryHash(text string) string {
@@ -23,6 +29,8 @@ func StoryHash(text string) string {
 
 func SpecHash(spec Spec) string {
 	payload := hashPayload{
+		Title:                spec.Title,
+		StrategicContext:     spec.StrategicContext,
 		StoryText:            spec.UserStory.Text,
 		Summary:              spec.Summary,
 		Requirements:         spec.Requirements,
@@ -31,6 +39,10 @@ func SpecHash(spec Spec) string {
 		CriticalUserJourneys: spec.CriticalUserJourneys,
 		MarketResearch:       spec.MarketResearch,
 		CompetitiveLandscape: spec.CompetitiveLandscape,
+		OSSLandscape:         spec.OSSLandscape,
+		Complexity:           spec.Complexity,
+		EstimatedMinutes:     spec.EstimatedMinutes,
+		Priority:             spec.Priority,
 	}
 	data, _ := json.Marshal(payload)
 	return hashBytes(data)
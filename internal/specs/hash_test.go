@@ -54,3 +54,34 @@ func TestSpecHashChangesWhenEvidenceChanges(t *testing.T) {
 		t.Fatalf("expected evidence change to affect hash")
 	}
 }
+
+func TestSpecHashChangesWhenOSSLandscapeChanges(t *testing.T) {
+	base := Spec{
+		Summary:      "Summary",
+		OSSLandscape: []OSSLandscapeItem{{ID: "OSS-001", Name: "Project", License: "MIT"}},
+	}
+	hashA := SpecHash(base)
+	base.OSSLandscape[0].License = "Apache-2.0"
+	hashB := SpecHash(base)
+	if hashA == hashB {
+		t.Fatalf("expected oss change to affect hash")
+	}
+}
+
+func TestSpecHashCoversScalarSections(t *testing.T) {
+	base := Spec{Title: "Title", Summary: "Summary", Complexity: "medium", EstimatedMinutes: 30, Priority: 1}
+	edits := map[string]func(*Spec){
+		"title":             func(s *Spec) { s.Title = "Other" },
+		"strategic_context": func(s *Spec) { s.StrategicContext.FeatureID = "FEAT-002" },
+		"complexity":        func(s *Spec) { s.Complexity = "high" },
+		"estimated_minutes": func(s *Spec) { s.EstimatedMinutes = 45 },
+		"priority":          func(s *Spec) { s.Priority = 2 },
+	}
+	for name, edit := range edits {
+		changed := base
+		edit(&changed)
+		if SpecHash(changed) == SpecHash(base) {
+			t.Fatalf("expected %s change to affect hash", name)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 )
 
 var idPattern = regexp.MustCompile(`^PRD-(\d+)\.ya?ml$`)
@@ -31,3 +32,29 @@ func NextID(dir string) (string, error) {
 	}
 	return fmt.Sprintf("PRD-%03d", next), nil
 }
+
+func NextItemID(prefix string, existing []string) string {
+	max, width := 0, 3
+	for _, id := range existing {
+		if !strings.HasPrefix(id, prefix) {
+			continue
+		}
+		digits := strings.TrimPrefix(id, prefix)
+		if n, err := strconv.Atoi(digits); err == nil && n > max {
+			max, width = n, len(digits)
+		}
+	}
+	return fmt.Sprintf("%s%0*d", prefix, width, max+1)
+}
+
+func IndexByID[T any](items []T, key string, id func(T) string) int {
+	if key == "" {
+		return -1
+	}
+	for i, item := range items {
+		if id(item) == key {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,20 @@
+package specs
+
+import "testing"
+
+func TestNextItemIDFollowsExistingWidth(t *testing.T) {
+	cases := []struct {
+		prefix   string
+		existing []string
+		want     string
+	}{
+		{"MR-", nil, "MR-001"},
+		{"MR-", []string{"MR-002", "COMP-009", "MR-x"}, "MR-003"},
+		{"ac-", []string{"ac-1", "ac-2"}, "ac-3"},
+	}
+	for _, c := range cases {
+		if got := NextItemID(c.prefix, c.existing); got != c.want {
+			t.Fatalf("NextItemID(%q, %v) = %q, want %q", c.prefix, c.existing, got, c.want)
+		}
+	}
+}
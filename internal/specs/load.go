@@ -1,6 +1,7 @@
 package specs
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -27,6 +28,14 @@ func LoadSpec(path string) (Spec, error) {
 	return doc, nil
 }
 
+func SaveSpec(path string, spec Spec) error {
+	raw, err := yaml.Marshal(&spec)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, raw, 0o644)
+}
+
 func LoadSummaries(dir string) ([]Summary, []string) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
@@ -61,3 +70,23 @@ func LoadSummaries(dir string) ([]Summary, []string) {
 	}
 	return out, warnings
 }
+
+func LoadAll(dir string) ([]Spec, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var out []Spec
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !(strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")) {
+			continue
+		}
+		spec, err := LoadSpec(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("load %s: %w", name, err)
+		}
+		out = append(out, spec)
+	}
+	return out, nil
+}
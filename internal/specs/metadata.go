@@ -28,6 +28,65 @@ func StoreValidationWarnings(path string, warnings []string) error {
 	return os.WriteFile(path, out, 0o644)
 }
 
+func RegisterResearch(path, ref string) error {
+	return updateResearchList(path, func(items []string) []string {
+		for _, item := range items {
+			if item == ref {
+				return items
+			}
+		}
+		return append(items, ref)
+	})
+}
+
+func UnregisterResearch(path, ref string) error {
+	return updateResearchList(path, func(items []string) []string {
+		out := items[:0]
+		for _, item := range items {
+			if item != ref {
+				out = append(out, item)
+			}
+		}
+		return out
+	})
+}
+
+func updateResearchList(path string, update func([]string) []string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return err
+	}
+	root := firstMapping(&doc)
+	if root == nil {
+		return nil
+	}
+	var current []string
+	if node := mappingValue(root, "research"); node != nil {
+		if err := node.Decode(&current); err != nil {
+			return err
+		}
+	}
+	setMappingValue(root, "research", sequenceNode(update(current)))
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, out, 0o644)
+}
+
+func mappingValue(parent *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == key {
+			return parent.Content[i+1]
+		}
+	}
+	return nil
+}
+
 func firstMapping(doc *yaml.Node) *yaml.Node {
 	if doc == nil {
 		return nil
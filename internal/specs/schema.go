@@ -56,6 +56,17 @@ type CompetitiveLandscapeItem struct {
 	EvidenceRefs []EvidenceRef `yaml:"evidence_refs"`
 }
 
+type OSSLandscapeItem struct {
+	ID                 string        `yaml:"id"`
+	Name               string        `yaml:"name"`
+	Repo               string        `yaml:"repo"`
+	License            string        `yaml:"license"`
+	Learnings          []string      `yaml:"learnings"`
+	Insights           []string      `yaml:"insights"`
+	ReusableComponents []string      `yaml:"reusable_components"`
+	EvidenceRefs       []EvidenceRef `yaml:"evidence_refs"`
+}
+
 type Metadata struct {
 	ValidationWarnings []string `yaml:"validation_warnings"`
 }
@@ -74,6 +85,7 @@ type Spec struct {
 	CriticalUserJourneys []CriticalUserJourney      `yaml:"critical_user_journeys"`
 	MarketResearch       []MarketResearchItem       `yaml:"market_research"`
 	CompetitiveLandscape []CompetitiveLandscapeItem `yaml:"competitive_landscape"`
+	OSSLandscape         []OSSLandscapeItem         `yaml:"oss_landscape,omitempty"`
 	Metadata             Metadata                   `yaml:"metadata"`
 	Complexity           string                     `yaml:"complexity"`
 	EstimatedMinutes     int                        `yaml:"estimated_minutes"`
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -16,10 +17,15 @@ const (
 )
 
 type ValidationOptions struct {
-	Mode ValidationMode
-	Root string
+	Mode            ValidationMode
+	Root            string
+	ConfidenceScale []string
+	MaxAgeDays      int
+	Now             time.Time
 }
 
+var DefaultConfidenceScale = []string{"low", "medium", "high"}
+
 type ValidationResult struct {
 	Errors   []string
 	Warnings []string
@@ -33,6 +39,12 @@ func Validate(raw []byte, opts ValidationOptions) (ValidationResult, error) {
 	if opts.Root == "" {
 		opts.Root = "."
 	}
+	if len(opts.ConfidenceScale) == 0 {
+		opts.ConfidenceScale = DefaultConfidenceScale
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
 	var doc Spec
 	if err := yaml.Unmarshal(raw, &doc); err != nil {
 		return res, err
@@ -44,6 +56,7 @@ func Validate(raw []byte, opts ValidationOptions) (ValidationResult, error) {
 	validateCUJs(&res, doc.CriticalUserJourneys, reqIDs, opts.Mode)
 	validateMarketResearch(&res, doc.MarketResearch, opts)
 	validateCompetitiveLandscape(&res, doc.CompetitiveLandscape, opts)
+	validateOSSLandscape(&res, doc.OSSLandscape, len(doc.Research) > 0 || hasSection(raw, "oss_landscape"), opts)
 	return res, nil
 }
 
@@ -89,6 +102,36 @@ func validateMarketResearch(res *ValidationResult, items []MarketResearchItem, o
 			seen[item.ID] = struct{}{}
 		}
 		validateEvidenceRefs(res, item.EvidenceRefs, opts, "market_research")
+		validateConfidence(res, item, opts)
+		validateFreshness(res, item, opts)
+	}
+}
+
+func validateConfidence(res *ValidationResult, item MarketResearchItem, opts ValidationOptions) {
+	if strings.TrimSpace(item.Confidence) == "" {
+		addModeIssue(res, opts.Mode, "market_research missing confidence: "+item.ID)
+		return
+	}
+	for _, level := range opts.ConfidenceScale {
+		if strings.EqualFold(level, item.Confidence) {
+			return
+		}
+	}
+	addModeIssue(res, opts.Mode, "market_research invalid confidence for "+item.ID+": "+item.Confidence)
+}
+
+func validateFreshness(res *ValidationResult, item MarketResearchItem, opts ValidationOptions) {
+	if strings.TrimSpace(item.Date) == "" {
+		addModeIssue(res, opts.Mode, "market_research missing date: "+item.ID)
+		return
+	}
+	date, err := ParseClaimDate(item.Date)
+	if err != nil {
+		addModeIssue(res, opts.Mode, "market_research invalid date for "+item.ID+": "+item.Date)
+		return
+	}
+	if isStale(date, opts.Now, opts.MaxAgeDays) {
+		res.Warnings = append(res.Warnings, "market_research stale claim: "+item.ID+" ("+item.Date+")")
 	}
 }
 
@@ -111,6 +154,27 @@ func validateCompetitiveLandscape(res *ValidationResult, items []CompetitiveLand
 	}
 }
 
+func validateOSSLandscape(res *ValidationResult, items []OSSLandscapeItem, expected bool, opts ValidationOptions) {
+	if len(items) == 0 {
+		if expected {
+			res.Warnings = append(res.Warnings, "oss landscape missing")
+		}
+		return
+	}
+	seen := make(map[string]struct{})
+	for _, item := range items {
+		if item.ID == "" {
+			res.Errors = append(res.Errors, "oss landscape id is required")
+		} else {
+			if _, ok := seen[item.ID]; ok {
+				res.Errors = append(res.Errors, "duplicate oss landscape id: "+item.ID)
+			}
+			seen[item.ID] = struct{}{}
+		}
+		validateEvidenceRefs(res, item.EvidenceRefs, opts, "oss_landscape")
+	}
+}
+
 func validateEvidenceRefs(res *ValidationResult, refs []EvidenceRef, opts ValidationOptions, section string) {
 	if len(refs) == 0 {
 		addModeIssue(res, opts.Mode, section+" missing evidence refs")
@@ -152,18 +216,25 @@ func validCUJPriority(priority string) bool {
 func requirementIDs(requirements []string) map[string]struct{} {
 	ids := make(map[string]struct{})
 	for _, req := range requirements {
-		fields := strings.Fields(req)
-		if len(fields) == 0 {
-			continue
-		}
-		token := strings.TrimSuffix(fields[0], ":")
-		if strings.HasPrefix(token, "REQ-") {
-			ids[token] = struct{}{}
+		if id := RequirementID(req); strings.HasPrefix(id, "REQ-") {
+			ids[id] = struct{}{}
 		}
 	}
 	return ids
 }
 
+func RequirementID(requirement string) string {
+	fields := strings.Fields(requirement)
+	if len(fields) == 0 {
+		return ""
+	}
+	token := strings.TrimSuffix(fields[0], ":")
+	if strings.HasPrefix(token, "REQ-") {
+		return token
+	}
+	return strings.TrimSpace(requirement)
+}
+
 func isResearchPath(path string) bool {
 	if filepath.IsAbs(path) {
 		return false
@@ -178,3 +249,12 @@ func isResearchPath(path string) bool {
 	}
 	return strings.HasPrefix(clean, prefix+string(os.PathSeparator))
 }
+
+func hasSection(raw []byte, key string) bool {
+	var doc map[string]yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return false
+	}
+	_, ok := doc[key]
+	return ok
+}
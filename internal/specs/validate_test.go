@@ -3,7 +3,9 @@ package specs
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestValidateMissingTitle(t *testing.T) {
@@ -239,3 +241,123 @@ requirements:
   - "REQ-001: Requirement one"
 `)
 }
+
+func TestValidateHardErrorsOnInvalidClaimDate(t *testing.T) {
+	root := t.TempDir()
+	raw := baseSpecYAML()
+	raw = []byte(string(raw) + `
+market_research:
+  - id: "MR-001"
+    claim: "Market is growing"
+    confidence: "medium"
+    date: "15/01/2026"
+`)
+	res, err := Validate(raw, ValidationOptions{Mode: ValidationHard, Root: root})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !containsIssue(res.Errors, "invalid date for MR-001") {
+		t.Fatalf("expected invalid date error, got %v", res.Errors)
+	}
+}
+
+func TestValidateHardErrorsOnConfidenceOutsideScale(t *testing.T) {
+	root := t.TempDir()
+	raw := baseSpecYAML()
+	raw = []byte(string(raw) + `
+market_research:
+  - id: "MR-001"
+    claim: "Market is growing"
+    confidence: "certain"
+    date: "2026-01-15"
+`)
+	res, err := Validate(raw, ValidationOptions{Mode: ValidationHard, Root: root})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !containsIssue(res.Errors, "invalid confidence for MR-001") {
+		t.Fatalf("expected invalid confidence error, got %v", res.Errors)
+	}
+	res, err = Validate(raw, ValidationOptions{Mode: ValidationHard, Root: root, ConfidenceScale: []string{"certain"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if containsIssue(res.Errors, "confidence") {
+		t.Fatalf("expected custom scale to accept confidence, got %v", res.Errors)
+	}
+}
+
+func TestValidateWarnsOnStaleClaim(t *testing.T) {
+	root := t.TempDir()
+	raw := baseSpecYAML()
+	raw = []byte(string(raw) + `
+market_research:
+  - id: "MR-001"
+    claim: "Market is growing"
+    confidence: "medium"
+    date: "2025-01-15"
+`)
+	now := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	res, err := Validate(raw, ValidationOptions{Mode: ValidationHard, Root: root, MaxAgeDays: 180, Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !containsIssue(res.Warnings, "stale claim: MR-001") {
+		t.Fatalf("expected stale warning, got %v", res.Warnings)
+	}
+	if containsIssue(res.Errors, "stale") {
+		t.Fatalf("expected stale claims to stay warnings")
+	}
+}
+
+func containsIssue(issues []string, fragment string) bool {
+	for _, issue := range issues {
+		if strings.Contains(issue, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestValidateHardErrorsOnDuplicateOSSLandscapeID(t *testing.T) {
+	root := t.TempDir()
+	raw := baseSpecYAML()
+	raw = []byte(string(raw) + `
+oss_landscape:
+  - id: "OSS-001"
+    name: "One"
+  - id: "OSS-001"
+    name: "Two"
+`)
+	res, err := Validate(raw, ValidationOptions{Mode: ValidationHard, Root: root})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !containsIssue(res.Errors, "duplicate oss landscape id: OSS-001") {
+		t.Fatalf("expected duplicate oss id error, got %v", res.Errors)
+	}
+	if !containsIssue(res.Errors, "oss_landscape missing evidence refs") {
+		t.Fatalf("expected missing evidence error, got %v", res.Errors)
+	}
+}
+
+func TestValidateWarnsOnMissingOSSLandscapeOnlyWhenExpected(t *testing.T) {
+	cases := []struct {
+		name  string
+		extra string
+		warn  bool
+	}{
+		{"legacy spec", "", false},
+		{"research attached", "research:\n  - \".praude/research/PRD-001-20260115-000000.md\"\n", true},
+		{"empty section", "oss_landscape: []\n", true},
+	}
+	for _, tc := range cases {
+		res, err := Validate([]byte(string(baseSpecYAML())+tc.extra), ValidationOptions{Mode: ValidationSoft, Root: t.TempDir()})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if got := containsIssue(res.Warnings, "oss landscape missing"); got != tc.warn {
+			t.Fatalf("%s: expected warning %v, got %v", tc.name, tc.warn, res.Warnings)
+		}
+	}
+}
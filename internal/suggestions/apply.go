@@ -0,0 +1,81 @@
+package suggestions
+
+import (
+	"os"
+	"strings"
+
+	"github.com/mistakeknot/praude/internal/specs"
+	"gopkg.in/yaml.v3"
+)
+
+type ValidationError struct {
+	Errors []string
+}
+
+func (e ValidationError) Error() string {
+	return "validation failed: " + strings.Join(e.Errors, "; ")
+}
+
+type ValidationDelta struct {
+	Result   specs.ValidationResult
+	New      []string
+	Resolved []string
+}
+
+func Apply(path string, suggestion Suggestion, opts specs.ValidationOptions) (ValidationDelta, error) {
+	if opts.Mode == "" {
+		opts.Mode = specs.ValidationSoft
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return ValidationDelta{}, err
+	}
+	var doc specs.Spec
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return ValidationDelta{}, err
+	}
+	before, err := specs.Validate(raw, opts)
+	if err != nil {
+		return ValidationDelta{}, err
+	}
+	Merge(&doc, suggestion)
+	after, err := ValidatePreview(doc, opts)
+	if err != nil {
+		return ValidationDelta{}, err
+	}
+	delta := ValidationDelta{
+		Result:   after,
+		New:      difference(issues(after), issues(before)),
+		Resolved: difference(issues(before), issues(after)),
+	}
+	if opts.Mode == specs.ValidationHard && len(after.Errors) > 0 {
+		return delta, ValidationError{Errors: after.Errors}
+	}
+	if err := specs.SaveSpec(path, doc); err != nil {
+		return delta, err
+	}
+	if opts.Mode == specs.ValidationSoft {
+		if err := specs.StoreValidationWarnings(path, after.Warnings); err != nil {
+			return delta, err
+		}
+	}
+	return delta, nil
+}
+
+func issues(res specs.ValidationResult) []string {
+	return append(append([]string{}, res.Errors...), res.Warnings...)
+}
+
+func difference(items, exclude []string) []string {
+	skip := make(map[string]bool, len(exclude))
+	for _, item := range exclude {
+		skip[item] = true
+	}
+	var out []string
+	for _, item := range items {
+		if !skip[item] {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,85 @@
+package suggestions
+
+import (
+	"strings"
+
+	"github.com/mistakeknot/praude/internal/specs"
+)
+
+type CompareEntry struct {
+	File  string
+	Label string
+	Items []Item
+}
+
+type SectionComparison struct {
+	Section string
+	Entries []CompareEntry
+}
+
+func CompareLabels(files []File) map[string]string {
+	seen := make(map[string]int)
+	for _, file := range files {
+		seen[file.Suggestion.Agent]++
+	}
+	labels := make(map[string]string, len(files))
+	for _, file := range files {
+		label := file.Suggestion.Agent
+		if label == "" || seen[label] > 1 {
+			label = file.Name
+		}
+		labels[file.Name] = label
+	}
+	return labels
+}
+
+func Compare(files []File, current specs.Spec) []SectionComparison {
+	labels := CompareLabels(files)
+	var out []SectionComparison
+	for _, key := range SectionKeys() {
+		section := SectionComparison{Section: key}
+		changed := false
+		for _, file := range files {
+			if file.Err != nil {
+				continue
+			}
+			entry := CompareEntry{File: file.Name, Label: labels[file.Name]}
+			for _, item := range Items(file.Suggestion, current) {
+				if item.Section == key {
+					entry.Items = append(entry.Items, item)
+				}
+			}
+			changed = changed || len(entry.Items) > 0
+			section.Entries = append(section.Entries, entry)
+		}
+		if changed {
+			out = append(out, section)
+		}
+	}
+	return out
+}
+
+func DescribeItem(item Item) string {
+	return strings.TrimSpace(string(item.Action) + " " + strings.TrimSpace(item.Label))
+}
+
+func RenderComparison(sections []SectionComparison) []string {
+	var lines []string
+	for _, section := range sections {
+		lines = append(lines, SectionTitle(section.Section))
+		for _, entry := range section.Entries {
+			if len(entry.Items) == 0 {
+				lines = append(lines, "  "+entry.Label+": (no change)")
+				continue
+			}
+			for i, item := range entry.Items {
+				source := entry.Label + ":"
+				if i > 0 {
+					source = strings.Repeat(" ", len(source))
+				}
+				lines = append(lines, "  "+source+" "+DescribeItem(item))
+			}
+		}
+	}
+	return lines
+}
@@ -0,0 +1,44 @@
+package suggestions
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mistakeknot/praude/internal/specs"
+)
+
+func writeAgentSuggestion(t *testing.T, dir, name, agent, body string) File {
+	t.Helper()
+	raw := "---\nformat: 2\nid: \"PRD-001\"\nagent: \"" + agent + "\"\n---\n" + body
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return Open(path)
+}
+
+func TestCompareLinesUpSectionsPerAgent(t *testing.T) {
+	dir := t.TempDir()
+	codex := writeAgentSuggestion(t, dir, "PRD-001-20260115-100000.md", "codex", "## Summary\n~~~yaml\nsummary: \"From codex\"\n~~~\n\n## Requirements\n~~~yaml\nrequirements:\n  - \"REQ-002: Export\"\n~~~\n")
+	claude := writeAgentSuggestion(t, dir, "PRD-001-20260115-100001.md", "claude", "## Summary\n~~~yaml\nsummary: \"From claude\"\n~~~\n")
+	current := specs.Spec{ID: "PRD-001", Summary: "Old", Requirements: []string{"REQ-001: Import"}}
+	sections := Compare([]File{codex, claude}, current)
+	if len(sections) != 2 || sections[0].Section != SectionSummary || sections[1].Section != SectionRequirements {
+		t.Fatalf("unexpected sections %+v", sections)
+	}
+	if len(sections[1].Entries) != 2 || len(sections[1].Entries[1].Items) != 0 {
+		t.Fatalf("expected claude to have no requirement changes, got %+v", sections[1].Entries)
+	}
+	out := strings.Join(RenderComparison(sections), "\n")
+	for _, want := range []string{"codex: ", "From codex", "claude: ", "From claude", "claude: (no change)"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in %q", want, out)
+		}
+	}
+	labels := CompareLabels([]File{codex, codex})
+	if labels[codex.Name] != codex.Name {
+		t.Fatalf("expected duplicate agents to be labelled by file, got %v", labels)
+	}
+}
@@ -0,0 +1,202 @@
+package suggestions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mistakeknot/praude/internal/specs"
+	"gopkg.in/yaml.v3"
+)
+
+type DiffOp byte
+
+const (
+	DiffSame   DiffOp = ' '
+	DiffAdd    DiffOp = '+'
+	DiffRemove DiffOp = '-'
+)
+
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+func (l DiffLine) String() string {
+	return string(l.Op) + " " + l.Text
+}
+
+type SectionDiff struct {
+	Key   string
+	Lines []DiffLine
+}
+
+func Preview(current specs.Spec, s Suggestion) specs.Spec {
+	out := current
+	Merge(&out, s)
+	return out
+}
+
+func ValidatePreview(spec specs.Spec, opts specs.ValidationOptions) (specs.ValidationResult, error) {
+	raw, err := yaml.Marshal(spec)
+	if err != nil {
+		return specs.ValidationResult{}, err
+	}
+	return specs.Validate(raw, opts)
+}
+
+func Diff(before, after specs.Spec) ([]SectionDiff, error) {
+	oldFields, oldKeys, err := specFields(before)
+	if err != nil {
+		return nil, err
+	}
+	newFields, newKeys, err := specFields(after)
+	if err != nil {
+		return nil, err
+	}
+	keys := append([]string{}, newKeys...)
+	for _, key := range oldKeys {
+		if _, ok := newFields[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	var out []SectionDiff
+	for _, key := range keys {
+		oldText, newText := oldFields[key], newFields[key]
+		if oldText == newText {
+			continue
+		}
+		out = append(out, SectionDiff{Key: key, Lines: diffLines(splitLines(oldText), splitLines(newText))})
+	}
+	return out, nil
+}
+
+func specFields(spec specs.Spec) (map[string]string, []string, error) {
+	var doc yaml.Node
+	if err := doc.Encode(spec); err != nil {
+		return nil, nil, err
+	}
+	fields := make(map[string]string)
+	var keys []string
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		key, value := doc.Content[i], doc.Content[i+1]
+		pair := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{key, value}}
+		raw, err := yaml.Marshal(pair)
+		if err != nil {
+			return nil, nil, err
+		}
+		fields[key.Value] = string(raw)
+		keys = append(keys, key.Value)
+	}
+	return fields, keys, nil
+}
+
+func splitLines(text string) []string {
+	text = strings.TrimRight(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+func diffLines(before, after []string) []DiffLine {
+	n, m := len(before), len(after)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var out []DiffLine
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case before[i] == after[j]:
+			out = append(out, DiffLine{Op: DiffSame, Text: before[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, DiffLine{Op: DiffRemove, Text: before[i]})
+			i++
+		default:
+			out = append(out, DiffLine{Op: DiffAdd, Text: after[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		out = append(out, DiffLine{Op: DiffRemove, Text: before[i]})
+	}
+	for ; j < m; j++ {
+		out = append(out, DiffLine{Op: DiffAdd, Text: after[j]})
+	}
+	return out
+}
+
+var (
+	diffAddStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	diffRemoveStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+)
+
+func RenderDiff(current specs.Spec, s Suggestion, opts specs.ValidationOptions) ([]string, error) {
+	after := Preview(current, s)
+	diffs, err := Diff(current, after)
+	if err != nil {
+		return nil, err
+	}
+	lines := renderSections(diffs)
+	res, err := ValidatePreview(after, opts)
+	if err != nil {
+		return lines, err
+	}
+	return append(lines, ValidationSummary(res)...), nil
+}
+
+func renderSections(diffs []SectionDiff) []string {
+	if len(diffs) == 0 {
+		return []string{"No changes"}
+	}
+	var out []string
+	for _, diff := range diffs {
+		out = append(out, "@@ "+diff.Key+" @@")
+		for _, line := range diff.Lines {
+			out = append(out, styleDiffLine(line))
+		}
+	}
+	return out
+}
+
+func styleDiffLine(line DiffLine) string {
+	switch line.Op {
+	case DiffAdd:
+		return diffAddStyle.Render(line.String())
+	case DiffRemove:
+		return diffRemoveStyle.Render(line.String())
+	}
+	return line.String()
+}
+
+func ValidationSummary(res specs.ValidationResult) []string {
+	var out []string
+	switch {
+	case len(res.Errors) > 0:
+		out = append(out, fmt.Sprintf("Validation after apply: %d errors", len(res.Errors)))
+	case len(res.Warnings) > 0:
+		out = append(out, fmt.Sprintf("Validation after apply: %d warnings", len(res.Warnings)))
+	default:
+		out = append(out, "Validation after apply: OK")
+	}
+	for _, msg := range res.Errors {
+		out = append(out, "ERROR: "+msg)
+	}
+	for _, msg := range res.Warnings {
+		out = append(out, "WARN: "+msg)
+	}
+	return out
+}
@@ -0,0 +1,63 @@
+package suggestions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mistakeknot/praude/internal/specs"
+)
+
+func TestDiffShowsChangedSectionsOnly(t *testing.T) {
+	current := specs.Spec{
+		ID:           "PRD-001",
+		Title:        "Alpha",
+		Summary:      "Old",
+		Requirements: []string{"REQ-001: Keep", "REQ-002: Old"},
+	}
+	sugg := Suggestion{Requirements: []Change[string]{{Value: "REQ-002: New"}}}
+	after := Preview(current, sugg)
+	if current.Requirements[1] != "REQ-002: Old" {
+		t.Fatalf("expected preview to leave current spec untouched")
+	}
+	diffs, err := Diff(current, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 1 || diffs[0].Key != "requirements" {
+		t.Fatalf("expected only requirements diff, got %+v", diffs)
+	}
+	want := []DiffLine{
+		{Op: DiffSame, Text: "requirements:"},
+		{Op: DiffSame, Text: "    - 'REQ-001: Keep'"},
+		{Op: DiffRemove, Text: "    - 'REQ-002: Old'"},
+		{Op: DiffAdd, Text: "    - 'REQ-002: New'"},
+	}
+	if len(diffs[0].Lines) != len(want) {
+		t.Fatalf("unexpected diff lines: %+v", diffs[0].Lines)
+	}
+	for i, line := range want {
+		if diffs[0].Lines[i] != line {
+			t.Fatalf("line %d: expected %+v, got %+v", i, line, diffs[0].Lines[i])
+		}
+	}
+}
+
+func TestRenderDiffAppendsValidationSummary(t *testing.T) {
+	current := specs.Spec{ID: "PRD-001", Title: "Alpha", Summary: "Old"}
+	lines, err := RenderDiff(current, Suggestion{Summary: "New"}, specs.ValidationOptions{Mode: specs.ValidationSoft})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) < 2 || lines[0] != "@@ summary @@" {
+		t.Fatalf("expected summary diff, got %q", lines)
+	}
+	found := false
+	for _, line := range lines {
+		if strings.HasPrefix(line, "Validation after apply: ") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected validation summary, got %q", lines)
+	}
+}
@@ -0,0 +1,145 @@
+package suggestions
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const FormatVersion = 2
+
+type frontMatter struct {
+	Format   int    `yaml:"format"`
+	ID       string `yaml:"id"`
+	BaseHash string `yaml:"base_hash"`
+	Agent    string `yaml:"agent"`
+	Parent   string `yaml:"parent"`
+}
+
+func hasFrontMatter(lines []string) bool {
+	return len(lines) > 0 && strings.TrimSpace(lines[0]) == "---"
+}
+
+func parseV2(lines []string) (Suggestion, error) {
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return Suggestion{}, fmt.Errorf("line 1: unterminated front matter")
+	}
+	var front frontMatter
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[1:end], "\n")), &front); err != nil {
+		return Suggestion{}, shiftLines(err, 1)
+	}
+	if front.Format != FormatVersion {
+		return Suggestion{}, fmt.Errorf("line 1: unsupported suggestion format %d", front.Format)
+	}
+	out := Suggestion{Reviews: make(map[string]SectionReview), BaseHash: front.BaseHash, Agent: front.Agent, Parent: front.Parent}
+	section := ""
+	seen := make(map[string]bool)
+	for i := end + 1; i < len(lines); i++ {
+		trim := strings.TrimSpace(lines[i])
+		switch {
+		case strings.HasPrefix(trim, "## "):
+			title := strings.TrimSpace(strings.TrimPrefix(trim, "## "))
+			section = sectionKeyForTitle(title)
+			if section == "" {
+				return Suggestion{}, fmt.Errorf("line %d: unknown section %q", i+1, title)
+			}
+			out.Reviews[section] = parseReview(lines[i+1:])
+		case strings.HasPrefix(trim, "```") || strings.HasPrefix(trim, "~~~"):
+			fence, lang := trim[:3], strings.TrimSpace(trim[3:])
+			if lang != "" && lang != "yaml" && lang != "yml" {
+				return Suggestion{}, fmt.Errorf("line %d: unsupported block language %q", i+1, lang)
+			}
+			close := -1
+			for j := i + 1; j < len(lines); j++ {
+				if strings.TrimSpace(lines[j]) == fence {
+					close = j
+					break
+				}
+			}
+			switch {
+			case close < 0:
+				return Suggestion{}, fmt.Errorf("line %d: unterminated yaml block", i+1)
+			case section == "":
+				return Suggestion{}, fmt.Errorf("line %d: yaml block outside a section", i+1)
+			case seen[section]:
+				return Suggestion{}, fmt.Errorf("line %d: duplicate yaml block for %s", i+1, SectionTitle(section))
+			}
+			seen[section] = true
+			if err := decodeFenced(&out, section, lines[i+1:close], i+1); err != nil {
+				return Suggestion{}, err
+			}
+			i = close
+		}
+	}
+	return out, nil
+}
+
+func decodeFenced(out *Suggestion, key string, body []string, offset int) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(body, "\n")), &doc); err != nil {
+		return shiftLines(err, offset)
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode || len(root.Content) != 2 || root.Content[0].Value != key {
+		return fmt.Errorf("line %d: expected a single %q key", root.Line+offset, key)
+	}
+	return shiftLines(decodeSection(out, key, root.Content[1]), offset)
+}
+
+var lineRef = regexp.MustCompile(`line (\d+)`)
+
+func shiftLines(err error, offset int) error {
+	if err == nil {
+		return nil
+	}
+	msg := lineRef.ReplaceAllStringFunc(err.Error(), func(ref string) string {
+		n, _ := strconv.Atoi(strings.TrimPrefix(ref, "line "))
+		return "line " + strconv.Itoa(n+offset)
+	})
+	return errors.New(strings.TrimPrefix(msg, "yaml: "))
+}
+
+func FillSections(path string, blocks map[string]string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(raw), "\n")
+	var out []string
+	section := ""
+	for i := 0; i < len(lines); i++ {
+		out = append(out, lines[i])
+		trim := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trim, "## ") {
+			section = sectionKeyForTitle(strings.TrimSpace(strings.TrimPrefix(trim, "## ")))
+			continue
+		}
+		if section == "" || !(strings.HasPrefix(trim, "```") || strings.HasPrefix(trim, "~~~")) {
+			continue
+		}
+		fence := trim[:3]
+		for i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != fence {
+			i++
+		}
+		if block := strings.TrimSpace(blocks[section]); block != "" {
+			out = append(out, block)
+		}
+		section = ""
+	}
+	return os.WriteFile(path, []byte(strings.Join(out, "\n")), 0o644)
+}
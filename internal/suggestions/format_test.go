@@ -0,0 +1,93 @@
+package suggestions
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/specs"
+)
+
+func TestCreateWritesV2FormatThatRoundTrips(t *testing.T) {
+	dir := t.TempDir()
+	path, err := Create(dir, "PRD-001", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(raw), "---\nformat: 2\n") {
+		t.Fatalf("expected v2 front matter, got %q", string(raw))
+	}
+	sugg, _, err := LoadLatest(dir, "PRD-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items := Items(sugg, specs.Spec{}); len(items) != 0 {
+		t.Fatalf("expected a fresh template to suggest nothing, got %+v", items)
+	}
+	if sugg.Reviews[SectionOSS].Status != StatusPending {
+		t.Fatalf("expected oss section parsed with pending status")
+	}
+	if !strings.Contains(string(raw), "# requirements:\n#   - \"REQ-001: Add requirement\"") {
+		t.Fatalf("expected commented-out examples, got %q", string(raw))
+	}
+	if err := os.WriteFile(path, []byte(strings.ReplaceAll(string(raw), "\n# ", "\n")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sugg, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sugg.Requirements) != 1 || sugg.Requirements[0].Value != "REQ-001: Add requirement" || len(sugg.OSSLandscape) != 1 {
+		t.Fatalf("expected uncommented examples to parse, got %+v", sugg)
+	}
+}
+
+func TestParseErrorsReportFileLineNumbers(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "v2 bad indentation",
+			body: "---\nformat: 2\nid: \"PRD-001\"\n---\n# Suggestions\n\n## Requirements\n- status: pending\n\n```yaml\nrequirements:\n  - \"REQ-001: One\"\n - \"REQ-002: Two\"\n```\n",
+			want: "line 12: did not find expected key",
+		},
+		{
+			name: "v2 wrong key",
+			body: "---\nformat: 2\n---\n## Summary\n```yaml\nsumary: \"typo\"\n```\n",
+			want: "line 6: expected a single \"summary\" key",
+		},
+		{
+			name: "v2 unterminated block",
+			body: "---\nformat: 2\n---\n## Summary\n```yaml\nsummary: \"x\"\n",
+			want: "line 5: unterminated yaml block",
+		},
+		{
+			name: "v1 unknown action",
+			body: "# Suggestions\n\n## Critical User Journeys\n- status: pending\n- suggestion:\n  - id: \"CUJ-001\"\n    action: \"replace\"\n",
+			want: "line 6: unknown action",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "PRD-001-20260115-000000.md"), []byte(tc.body), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, _, err := LoadLatest(dir, "PRD-001")
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+			if !strings.Contains(err.Error(), "PRD-001-20260115-000000.md") {
+				t.Fatalf("expected file name in error, got %v", err)
+			}
+		})
+	}
+}
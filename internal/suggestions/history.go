@@ -0,0 +1,178 @@
+package suggestions
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type ApplyRecord struct {
+	ID          string   `yaml:"id"`
+	Suggestion  string   `yaml:"suggestion"`
+	Sections    []string `yaml:"sections"`
+	AppliedBy   string   `yaml:"applied_by"`
+	AppliedAt   string   `yaml:"applied_at"`
+	AppliedHash string   `yaml:"applied_hash"`
+	RevertedBy  string   `yaml:"reverted_by,omitempty"`
+	RevertedAt  string   `yaml:"reverted_at,omitempty"`
+	Previous    string   `yaml:"previous"`
+	Path        string   `yaml:"-"`
+}
+
+func HistoryDir(dir string) string {
+	return filepath.Join(dir, "history")
+}
+
+func AppliedSections(offered []Item, accepted func(Item) bool) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, key := range SectionKeys() {
+		for _, item := range offered {
+			if item.Section == key && accepted(item) && !seen[key] {
+				seen[key] = true
+				out = append(out, key)
+			}
+		}
+	}
+	return out
+}
+
+func RecordApply(dir, id, specPath, suggPath string, previous []byte, sections []string, by string, now time.Time) (string, error) {
+	current, err := os.ReadFile(specPath)
+	if err != nil {
+		return "", err
+	}
+	rec := ApplyRecord{
+		ID:          id,
+		Suggestion:  filepath.Base(suggPath),
+		Sections:    sections,
+		AppliedBy:   by,
+		AppliedAt:   now.UTC().Format(time.RFC3339),
+		AppliedHash: contentHash(current),
+		Previous:    string(previous),
+	}
+	histDir := HistoryDir(dir)
+	if err := os.MkdirAll(histDir, 0o755); err != nil {
+		return "", err
+	}
+	base := id + "-" + now.UTC().Format(stampLayout)
+	path := filepath.Join(histDir, base+".yaml")
+	for n := 2; ; n++ {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			break
+		}
+		path = filepath.Join(histDir, fmt.Sprintf("%s-%d.yaml", base, n))
+	}
+	rec.Path = path
+	return path, writeRecord(rec)
+}
+
+func History(dir, id string) ([]ApplyRecord, error) {
+	entries, err := os.ReadDir(HistoryDir(dir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []ApplyRecord
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, id+"-") || !strings.HasSuffix(name, ".yaml") {
+			continue
+		}
+		path := filepath.Join(HistoryDir(dir), name)
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var rec ApplyRecord
+		if err := yaml.Unmarshal(raw, &rec); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if rec.ID != id {
+			continue
+		}
+		rec.Path = path
+		out = append(out, rec)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].AppliedAt != out[j].AppliedAt {
+			return out[i].AppliedAt < out[j].AppliedAt
+		}
+		return out[i].Path < out[j].Path
+	})
+	return out, nil
+}
+
+func LastApply(dir, id string) (ApplyRecord, error) {
+	records, err := History(dir, id)
+	if err != nil {
+		return ApplyRecord{}, err
+	}
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].RevertedAt == "" {
+			return records[i], nil
+		}
+	}
+	return ApplyRecord{}, fmt.Errorf("no applied suggestions to undo for %s", id)
+}
+
+func Undo(dir, id, specPath string, force bool, by string, now time.Time) (ApplyRecord, error) {
+	rec, err := LastApply(dir, id)
+	if err != nil {
+		return rec, err
+	}
+	current, err := os.ReadFile(specPath)
+	if err != nil {
+		return rec, err
+	}
+	if !force && contentHash(current) != rec.AppliedHash {
+		return rec, fmt.Errorf("%s changed since suggestions were applied at %s (use --force to restore anyway)", id, rec.AppliedAt)
+	}
+	if err := os.WriteFile(specPath, []byte(rec.Previous), 0o644); err != nil {
+		return rec, err
+	}
+	rec.RevertedBy = by
+	rec.RevertedAt = now.UTC().Format(time.RFC3339)
+	if err := writeRecord(rec); err != nil {
+		return rec, err
+	}
+	return rec, markReverted(filepath.Join(dir, rec.Suggestion), rec.Sections, by, now)
+}
+
+func markReverted(path string, sections []string, by string, now time.Time) error {
+	sugg, err := Load(path)
+	if err != nil {
+		return err
+	}
+	reviews := make(map[string]SectionReview)
+	for _, key := range sections {
+		review := sugg.Reviews[key]
+		review.Status = StatusReverted
+		review.ReviewedBy = by
+		review.ReviewedAt = now.UTC().Format(time.RFC3339)
+		reviews[key] = review
+	}
+	return WriteReviews(path, reviews)
+}
+
+func contentHash(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+func writeRecord(rec ApplyRecord) error {
+	out, err := yaml.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(rec.Path, out, 0o644)
+}
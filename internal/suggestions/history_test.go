@@ -0,0 +1,103 @@
+package suggestions
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/specs"
+)
+
+func TestUndoRestoresPreviousSpec(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "PRD-001.yaml")
+	specRaw := []byte("id: \"PRD-001\"\ntitle: \"Title\"\nsummary: \"Old\"\n")
+	if err := os.WriteFile(specPath, specRaw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	suggPath, err := Create(dir, "PRD-001", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(suggPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(suggPath, []byte(strings.Replace(string(raw), `# summary: ""`, `summary: "New"`, 1)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sugg, err := Load(suggPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Apply(specPath, sugg, specs.ValidationOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	items := Items(sugg, specs.Spec{})
+	all := func(Item) bool { return true }
+	if err := RecordReview(suggPath, items, all, "alice", now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RecordApply(dir, "PRD-001", specPath, suggPath, specRaw, AppliedSections(items, all), "alice", now); err != nil {
+		t.Fatal(err)
+	}
+	rec, err := Undo(dir, "PRD-001", specPath, false, "bob", now.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.RevertedBy != "bob" || len(rec.Sections) == 0 || rec.Sections[0] != SectionSummary {
+		t.Fatalf("unexpected record: %+v", rec)
+	}
+	restored, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(restored) != string(specRaw) {
+		t.Fatalf("expected original spec, got %q", restored)
+	}
+	if status := Open(suggPath).Status(); status != StatusReverted {
+		t.Fatalf("expected reverted, got %s", status)
+	}
+	if _, err := Undo(dir, "PRD-001", specPath, false, "bob", now); err == nil || !strings.Contains(err.Error(), "no applied suggestions") {
+		t.Fatalf("expected nothing left to undo, got %v", err)
+	}
+}
+
+func TestUndoRefusesAfterLaterEdits(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "PRD-001.yaml")
+	specRaw := []byte("id: \"PRD-001\"\ntitle: \"Title\"\nsummary: \"Old\"\n")
+	if err := os.WriteFile(specPath, specRaw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	suggPath, err := Create(dir, "PRD-001", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Apply(specPath, Suggestion{Summary: "New"}, specs.ValidationOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RecordApply(dir, "PRD-001", specPath, suggPath, specRaw, []string{SectionSummary}, "alice", now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Apply(specPath, Suggestion{Title: "Edited"}, specs.ValidationOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Undo(dir, "PRD-001", specPath, false, "bob", now); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("expected refusal, got %v", err)
+	}
+	if _, err := Undo(dir, "PRD-001", specPath, true, "bob", now); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(restored) != string(specRaw) {
+		t.Fatalf("expected forced restore, got %q", restored)
+	}
+}
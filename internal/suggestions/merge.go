@@ -0,0 +1,185 @@
+package suggestions
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/mistakeknot/praude/internal/specs"
+	"gopkg.in/yaml.v3"
+)
+
+type Action string
+
+const (
+	ActionUpsert Action = ""
+	ActionAdd    Action = "add"
+	ActionUpdate Action = "update"
+	ActionRemove Action = "remove"
+)
+
+type Change[T any] struct {
+	Value  T
+	Action Action
+}
+
+func (c *Change[T]) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return node.Decode(&c.Value)
+	}
+	rest := &yaml.Node{Kind: yaml.MappingNode, Tag: node.Tag, Line: node.Line, Column: node.Column}
+	ownsAction := hasYAMLField(reflect.TypeOf(c.Value), "action")
+	var text *yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch {
+		case key.Value == "change", key.Value == "action" && !ownsAction:
+			c.Action = Action(strings.ToLower(strings.TrimSpace(value.Value)))
+		case key.Value == "text":
+			text = value
+			rest.Content = append(rest.Content, key, value)
+		default:
+			rest.Content = append(rest.Content, key, value)
+		}
+	}
+	switch c.Action {
+	case ActionUpsert, ActionAdd, ActionUpdate, ActionRemove:
+	default:
+		return fmt.Errorf("line %d: unknown action %q", node.Line, c.Action)
+	}
+	if _, ok := any(c.Value).(string); ok && text != nil {
+		return text.Decode(&c.Value)
+	}
+	return rest.Decode(&c.Value)
+}
+
+type Item struct {
+	Section string
+	Index   int
+	ID      string
+	Action  Action
+	Label   string
+	Reason  string
+}
+
+func hasYAMLField(t reflect.Type, name string) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		tag := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if tag == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (i Item) Key() string {
+	return fmt.Sprintf("%s:%d", i.Section, i.Index)
+}
+
+func Items(s Suggestion, current specs.Spec) []Item {
+	var out []Item
+	for _, sec := range sections {
+		out = append(out, sec.items(s, current)...)
+	}
+	return out
+}
+
+func Select(s Suggestion, keep func(Item) bool) Suggestion {
+	out := s
+	for _, sec := range sections {
+		key := sec.key
+		sec.keep(&out, func(index int) bool {
+			return keep(Item{Section: key, Index: index})
+		})
+	}
+	return out
+}
+
+func Merge(spec *specs.Spec, s Suggestion) {
+	story := spec.UserStory
+	for _, sec := range sections {
+		sec.merge(spec, s)
+	}
+	if spec.UserStory != story {
+		spec.UserStory.Hash = specs.StoryHash(spec.UserStory.Text)
+	}
+}
+
+const (
+	ReasonSpecChanged   = "changed in the spec since suggestions were generated"
+	ReasonAddExisting   = "marked add but the id already exists"
+	ReasonUpdateMissing = "marked update but the id does not exist"
+)
+
+func Conflicts(base, current specs.Spec, s Suggestion) []Item {
+	changed := specs.SpecHash(base) != specs.SpecHash(current)
+	items := Items(s, current)
+	var out []Item
+	for _, sec := range sections {
+		reasons := make(map[int]string)
+		if changed {
+			for _, index := range sec.clash(base, current, s) {
+				reasons[index] = ReasonSpecChanged
+			}
+		}
+		if sec.mismatch != nil {
+			for index, reason := range sec.mismatch(current, s) {
+				reasons[index] = reason
+			}
+		}
+		for _, item := range items {
+			if reason, ok := reasons[item.Index]; ok && item.Section == sec.key {
+				item.Reason = reason
+				out = append(out, item)
+			}
+		}
+	}
+	return out
+}
+
+func lookupByID[T any](items []T, key string, id func(T) string) (T, bool) {
+	var zero T
+	if idx := specs.IndexByID(items, key, id); idx >= 0 {
+		return items[idx], true
+	}
+	return zero, false
+}
+
+func mergeByID[T any](current []T, changes []Change[T], id func(T) string) []T {
+	out := append([]T(nil), current...)
+	for _, change := range changes {
+		idx := specs.IndexByID(out, id(change.Value), id)
+		switch {
+		case change.Action == ActionRemove:
+			if idx >= 0 {
+				out = append(out[:idx], out[idx+1:]...)
+			}
+		case idx >= 0:
+			out[idx] = change.Value
+		default:
+			out = append(out, change.Value)
+		}
+	}
+	return out
+}
+
+func joinLabel(id, text string) string {
+	if strings.TrimSpace(text) == "" {
+		return id
+	}
+	return id + " " + text
+}
+
+func CheckConflicts(dir string, s Suggestion, current specs.Spec) ([]Item, error) {
+	if s.BaseHash == "" || s.BaseHash == specs.SpecHash(current) {
+		return Conflicts(current, current, s), nil
+	}
+	base, err := LoadBase(dir, s.BaseHash)
+	if err != nil {
+		return nil, err
+	}
+	return Conflicts(base, current, s), nil
+}
@@ -0,0 +1,312 @@
+package suggestions
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/specs"
+	"gopkg.in/yaml.v3"
+)
+
+func TestChangeUnmarshalReadsActionMarkers(t *testing.T) {
+	raw := []byte(`requirements:
+  - "REQ-001: Plain"
+  - text: "REQ-002"
+    action: "remove"
+cujs:
+  - id: "CUJ-001"
+    title: "Journey"
+    action: "update"
+`)
+	var doc struct {
+		Requirements []Change[string]                    `yaml:"requirements"`
+		CUJs         []Change[specs.CriticalUserJourney] `yaml:"cujs"`
+	}
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Requirements[0].Value != "REQ-001: Plain" || doc.Requirements[0].Action != ActionUpsert {
+		t.Fatalf("unexpected plain requirement: %+v", doc.Requirements[0])
+	}
+	if doc.Requirements[1].Value != "REQ-002" || doc.Requirements[1].Action != ActionRemove {
+		t.Fatalf("unexpected removal: %+v", doc.Requirements[1])
+	}
+	if doc.CUJs[0].Value.Title != "Journey" || doc.CUJs[0].Action != ActionUpdate {
+		t.Fatalf("unexpected cuj change: %+v", doc.CUJs[0])
+	}
+	bad := []byte("items:\n  - id: \"CUJ-001\"\n    action: \"explode\"\n")
+	var wrapper struct {
+		Items []Change[specs.CriticalUserJourney] `yaml:"items"`
+	}
+	if err := yaml.Unmarshal(bad, &wrapper); err == nil {
+		t.Fatalf("expected unknown action error")
+	}
+}
+
+func TestMergeKeepsItemsTheSuggestionDidNotMention(t *testing.T) {
+	spec := specs.Spec{
+		Requirements: []string{"REQ-001: One", "REQ-002: Two", "REQ-003: Three"},
+		CriticalUserJourneys: []specs.CriticalUserJourney{
+			{ID: "CUJ-001", Title: "First"},
+			{ID: "CUJ-002", Title: "Second"},
+		},
+	}
+	sugg := Suggestion{
+		Requirements: []Change[string]{
+			{Value: "REQ-002: Two, revised"},
+			{Value: "REQ-003", Action: ActionRemove},
+			{Value: "REQ-004: Four"},
+		},
+		CriticalUserJourneys: []Change[specs.CriticalUserJourney]{
+			{Value: specs.CriticalUserJourney{ID: "CUJ-003", Title: "Third"}},
+		},
+	}
+	Merge(&spec, sugg)
+	want := []string{"REQ-001: One", "REQ-002: Two, revised", "REQ-004: Four"}
+	if len(spec.Requirements) != len(want) {
+		t.Fatalf("unexpected requirements: %v", spec.Requirements)
+	}
+	for i := range want {
+		if spec.Requirements[i] != want[i] {
+			t.Fatalf("unexpected requirements: %v", spec.Requirements)
+		}
+	}
+	if len(spec.CriticalUserJourneys) != 3 || spec.CriticalUserJourneys[0].Title != "First" {
+		t.Fatalf("expected existing cujs kept, got %+v", spec.CriticalUserJourneys)
+	}
+}
+
+func TestItemsAndSelectFilterIndividualChanges(t *testing.T) {
+	current := specs.Spec{Requirements: []string{"REQ-001: One"}}
+	sugg := Suggestion{
+		Summary: "New",
+		Requirements: []Change[string]{
+			{Value: "REQ-001: One, revised"},
+			{Value: "REQ-002: Two"},
+		},
+	}
+	items := Items(sugg, current)
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(items))
+	}
+	if items[1].ID != "REQ-001" || items[1].Action != ActionUpdate {
+		t.Fatalf("expected update for existing requirement, got %+v", items[1])
+	}
+	if items[2].Action != ActionAdd {
+		t.Fatalf("expected add for new requirement, got %+v", items[2])
+	}
+	selected := Select(sugg, func(item Item) bool {
+		return item.Key() == items[2].Key()
+	})
+	if selected.Summary != "" {
+		t.Fatalf("expected summary skipped")
+	}
+	if len(selected.Requirements) != 1 || selected.Requirements[0].Value != "REQ-002: Two" {
+		t.Fatalf("unexpected selection: %+v", selected.Requirements)
+	}
+}
+
+func TestCheckConflictsFlagsItemsChangedOnBothSides(t *testing.T) {
+	dir := t.TempDir()
+	base := specs.Spec{
+		ID:           "PRD-001",
+		Summary:      "Base",
+		Requirements: []string{"REQ-001: One", "REQ-002: Two"},
+	}
+	path, err := CreateFromSpec(dir, base, "codex", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := FillSections(path, map[string]string{SectionRequirements: "requirements:\n  - \"REQ-001: Agent one\"\n  - \"REQ-002: Agent two\""}); err != nil {
+		t.Fatal(err)
+	}
+	sugg, _, err := LoadLatest(dir, "PRD-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sugg.BaseHash != specs.SpecHash(base) {
+		t.Fatalf("expected base hash recorded, got %q", sugg.BaseHash)
+	}
+	conflicts, err := CheckConflicts(dir, sugg, base)
+	if err != nil || len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts against unchanged spec, got %v %v", conflicts, err)
+	}
+	current := base
+	current.Requirements = []string{"REQ-001: One", "REQ-002: Human two"}
+	conflicts, err = CheckConflicts(dir, sugg, current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 1 || conflicts[0].ID != "REQ-002" {
+		t.Fatalf("expected REQ-002 conflict, got %+v", conflicts)
+	}
+}
+
+func TestMergeCoversEverySpecSection(t *testing.T) {
+	raw := []byte(`---
+format: 2
+---
+## Title
+~~~yaml
+title: "Sharper title"
+~~~
+
+## User Story
+~~~yaml
+user_story:
+  text: "As a PM, I want diffs so that I trust suggestions."
+~~~
+
+## Files to Modify
+~~~yaml
+files_to_modify:
+  - action: "create"
+    path: "internal/new.go"
+    description: "New file"
+  - path: "internal/old.go"
+    change: "remove"
+~~~
+
+## Estimated Minutes
+~~~yaml
+estimated_minutes: 90
+~~~
+`)
+	sugg, err := parseSuggestion(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current := specs.Spec{
+		Title:         "Old title",
+		Complexity:    "low",
+		FilesToModify: []specs.FileChange{{Action: "modify", Path: "internal/old.go"}},
+	}
+	var sections []string
+	for _, item := range Items(sugg, current) {
+		sections = append(sections, item.Section)
+	}
+	want := []string{SectionSpecTitle, SectionUserStory, SectionFiles, SectionFiles, SectionEstimate}
+	if strings.Join(sections, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected items %v, got %v", want, sections)
+	}
+	Merge(&current, sugg)
+	if current.Title != "Sharper title" || current.EstimatedMinutes != 90 || current.Complexity != "low" {
+		t.Fatalf("unexpected scalar merge: %+v", current)
+	}
+	if current.UserStory.Text == "" {
+		t.Fatalf("expected user story merged")
+	}
+	if len(current.FilesToModify) != 1 || current.FilesToModify[0].Path != "internal/new.go" || current.FilesToModify[0].Action != "create" {
+		t.Fatalf("unexpected files: %+v", current.FilesToModify)
+	}
+}
+
+func TestCheckConflictsFlagsScalarEdits(t *testing.T) {
+	dir := t.TempDir()
+	base := specs.Spec{ID: "PRD-001", Title: "Alpha", Summary: "Base", Priority: 1}
+	path, err := CreateFromSpec(dir, base, "codex", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := FillSections(path, map[string]string{SectionSpecTitle: `title: "Agent title"`, SectionPriority: "priority: 3"}); err != nil {
+		t.Fatal(err)
+	}
+	sugg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current := base
+	current.Title = "Human title"
+	current.Priority = 2
+	conflicts, err := CheckConflicts(dir, sugg, current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 2 || conflicts[0].Section != SectionSpecTitle || conflicts[1].Section != SectionPriority {
+		t.Fatalf("expected title and priority conflicts, got %+v", conflicts)
+	}
+}
+
+func TestCheckConflictsFlagsMismatchedMarkers(t *testing.T) {
+	dir := t.TempDir()
+	base := specs.Spec{ID: "PRD-001", Summary: "Base", Requirements: []string{"REQ-001: One"}}
+	path, err := CreateFromSpec(dir, base, "codex", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := `requirements:
+  - change: add
+    text: "REQ-001: Replaced"
+  - change: update
+    text: "REQ-009: Missing"
+  - change: add
+    text: "REQ-002: New"`
+	if err := FillSections(path, map[string]string{SectionRequirements: block}); err != nil {
+		t.Fatal(err)
+	}
+	sugg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conflicts, err := CheckConflicts(dir, sugg, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 2 {
+		t.Fatalf("expected two marker conflicts, got %+v", conflicts)
+	}
+	if conflicts[0].ID != "REQ-001" || conflicts[0].Reason != ReasonAddExisting {
+		t.Fatalf("expected add on existing REQ-001, got %+v", conflicts[0])
+	}
+	if conflicts[1].ID != "REQ-009" || conflicts[1].Reason != ReasonUpdateMissing {
+		t.Fatalf("expected update on missing REQ-009, got %+v", conflicts[1])
+	}
+}
+
+func TestMergeAppliesExplicitZeroValuesAndRehashesStory(t *testing.T) {
+	dir := t.TempDir()
+	current := specs.Spec{
+		ID:               "PRD-001",
+		Title:            "Alpha",
+		Summary:          "Summary",
+		Priority:         2,
+		EstimatedMinutes: 30,
+		StrategicContext: specs.StrategicContext{CUJID: "CUJ-001", MVPIncluded: true},
+		UserStory:        specs.UserStory{Text: "Old story", Hash: "pending"},
+	}
+	path, err := CreateFromSpec(dir, current, "codex", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks := map[string]string{
+		SectionPriority:  "priority: 0",
+		SectionEstimate:  "estimated_minutes: 0",
+		SectionContext:   "strategic_context:\n  cuj_id: \"CUJ-001\"\n  mvp_included: false",
+		SectionUserStory: "user_story:\n  text: \"New story\"",
+	}
+	if err := FillSections(path, blocks); err != nil {
+		t.Fatal(err)
+	}
+	sugg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items := Items(sugg, current); len(items) != 4 {
+		t.Fatalf("expected four value suggestions, got %+v", items)
+	}
+	Merge(&current, sugg)
+	if current.Priority != 0 || current.EstimatedMinutes != 0 || current.StrategicContext.MVPIncluded {
+		t.Fatalf("expected zero values applied, got %+v", current)
+	}
+	if current.UserStory.Text != "New story" || current.UserStory.Hash != specs.StoryHash("New story") {
+		t.Fatalf("expected story rehashed, got %+v", current.UserStory)
+	}
+	skipped := Select(sugg, func(item Item) bool { return item.Section != SectionPriority })
+	if items := Items(skipped, current); len(items) != 3 {
+		t.Fatalf("expected deselected priority dropped, got %+v", items)
+	}
+	if items := Items(sugg, current); len(items) != 4 {
+		t.Fatalf("expected selection to leave the original untouched, got %+v", items)
+	}
+}
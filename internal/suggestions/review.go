@@ -0,0 +1,341 @@
+package suggestions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/git"
+	"github.com/mistakeknot/praude/internal/specs"
+)
+
+const stampLayout = "20060102-150405"
+
+type ReviewStatus string
+
+const (
+	StatusPending  ReviewStatus = "pending"
+	StatusAccepted ReviewStatus = "accepted"
+	StatusRejected ReviewStatus = "rejected"
+	StatusPartial  ReviewStatus = "partial"
+	StatusInvalid  ReviewStatus = "invalid"
+	StatusReverted ReviewStatus = "reverted"
+)
+
+type SectionReview struct {
+	Status     ReviewStatus
+	ReviewedBy string
+	ReviewedAt string
+	Reason     string
+	Items      map[string]ReviewStatus
+}
+
+type File struct {
+	Path       string
+	Name       string
+	Suggestion Suggestion
+	CreatedAt  time.Time
+	Err        error
+}
+
+func (f File) Status() ReviewStatus {
+	if f.Err != nil {
+		return StatusInvalid
+	}
+	return f.Suggestion.Status()
+}
+
+func Open(path string) File {
+	sugg, err := Load(path)
+	name := filepath.Base(path)
+	return File{Path: path, Name: name, Suggestion: sugg, CreatedAt: createdAt(name), Err: err}
+}
+
+func createdAt(name string) time.Time {
+	stamp := strings.TrimSuffix(name, ".md")
+	if len(stamp) < len(stampLayout) {
+		return time.Time{}
+	}
+	t, err := time.Parse(stampLayout, stamp[len(stamp)-len(stampLayout):])
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func (s Suggestion) Status() ReviewStatus {
+	seen := map[ReviewStatus]bool{}
+	for _, item := range Items(s, specs.Spec{}) {
+		status := s.Reviews[item.Section].Status
+		if status == "" {
+			status = StatusPending
+		}
+		seen[status] = true
+	}
+	switch {
+	case len(seen) == 0, seen[StatusPending]:
+		return StatusPending
+	case len(seen) == 1 && seen[StatusAccepted]:
+		return StatusAccepted
+	case len(seen) == 1 && seen[StatusRejected]:
+		return StatusRejected
+	case len(seen) == 1 && seen[StatusReverted]:
+		return StatusReverted
+	default:
+		return StatusPartial
+	}
+}
+
+func List(dir, id string) ([]File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var out []File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, id+"-") || !strings.HasSuffix(name, ".md") {
+			continue
+		}
+		out = append(out, Open(filepath.Join(dir, name)))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+func Reviewer(root string) string {
+	if name := git.UserName(root); name != "" {
+		return name
+	}
+	return os.Getenv("USER")
+}
+
+func RecordReview(path string, offered []Item, accepted func(Item) bool, reviewer string, now time.Time) error {
+	return WriteReviews(path, mergeReviews(path, buildReviews(offered, accepted, reviewer, now)))
+}
+
+func RecordRejection(path string, offered []Item, reasons map[string]string, reviewer string, now time.Time) error {
+	reviews := buildReviews(offered, func(Item) bool { return false }, reviewer, now)
+	for key, reason := range reasons {
+		if review, ok := reviews[key]; ok && strings.TrimSpace(reason) != "" {
+			review.Reason = strings.TrimSpace(reason)
+			reviews[key] = review
+		}
+	}
+	return WriteReviews(path, mergeReviews(path, reviews))
+}
+
+func mergeReviews(path string, reviews map[string]SectionReview) map[string]SectionReview {
+	existing, err := Load(path)
+	if err != nil {
+		return reviews
+	}
+	for key, review := range reviews {
+		previous, ok := existing.Reviews[key]
+		if !ok {
+			continue
+		}
+		items := make(map[string]ReviewStatus, len(previous.Items)+len(review.Items))
+		for item, status := range previous.Items {
+			items[item] = status
+		}
+		for item, status := range review.Items {
+			items[item] = status
+		}
+		review.Items = items
+		review.Status = combineStatuses(items)
+		review.ReviewedBy = joinReviewers(previous.ReviewedBy, review.ReviewedBy)
+		if review.Reason == "" && review.Status != StatusAccepted {
+			review.Reason = previous.Reason
+		}
+		reviews[key] = review
+	}
+	return reviews
+}
+
+func joinReviewers(previous, reviewer string) string {
+	names := strings.Split(previous, ", ")
+	for _, name := range names {
+		if name == reviewer {
+			return previous
+		}
+	}
+	if previous == "" {
+		return reviewer
+	}
+	if reviewer == "" {
+		return previous
+	}
+	return previous + ", " + reviewer
+}
+
+func buildReviews(offered []Item, accepted func(Item) bool, reviewer string, now time.Time) map[string]SectionReview {
+	reviews := make(map[string]SectionReview)
+	for _, item := range offered {
+		review, ok := reviews[item.Section]
+		if !ok {
+			review = SectionReview{
+				ReviewedBy: reviewer,
+				ReviewedAt: now.UTC().Format(time.RFC3339),
+				Items:      make(map[string]ReviewStatus),
+			}
+		}
+		status := StatusRejected
+		if accepted(item) {
+			status = StatusAccepted
+		}
+		review.Items[reviewItemKey(item)] = status
+		reviews[item.Section] = review
+	}
+	for key, review := range reviews {
+		review.Status = combineStatuses(review.Items)
+		reviews[key] = review
+	}
+	return reviews
+}
+
+func WriteReviews(path string, reviews map[string]SectionReview) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(raw), "\n")
+	var out []string
+	for i := 0; i < len(lines); i++ {
+		out = append(out, lines[i])
+		trim := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(trim, "## ") {
+			continue
+		}
+		key := sectionKeyForTitle(strings.TrimSpace(strings.TrimPrefix(trim, "## ")))
+		review, ok := reviews[key]
+		if !ok {
+			continue
+		}
+		out = append(out, renderReview(review)...)
+		for i+1 < len(lines) && isReviewLine(lines, i+1) {
+			i++
+		}
+	}
+	return os.WriteFile(path, []byte(strings.Join(out, "\n")), 0o644)
+}
+
+func renderReview(review SectionReview) []string {
+	lines := []string{"- status: " + string(review.Status)}
+	if review.ReviewedBy != "" {
+		lines = append(lines, fmt.Sprintf("- reviewed_by: %q", review.ReviewedBy))
+	}
+	if review.ReviewedAt != "" {
+		lines = append(lines, fmt.Sprintf("- reviewed_at: %q", review.ReviewedAt))
+	}
+	if review.Reason != "" {
+		lines = append(lines, fmt.Sprintf("- reason: %q", review.Reason))
+	}
+	if len(review.Items) > 0 {
+		lines = append(lines, "- items:")
+		keys := make([]string, 0, len(review.Items))
+		for key := range review.Items {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			lines = append(lines, fmt.Sprintf("  - %q: %s", key, review.Items[key]))
+		}
+	}
+	return lines
+}
+
+func isReviewLine(lines []string, idx int) bool {
+	trim := strings.TrimSpace(lines[idx])
+	for _, prefix := range []string{"- status:", "- reviewed_by:", "- reviewed_at:", "- reason:", "- items:"} {
+		if strings.HasPrefix(trim, prefix) {
+			return true
+		}
+	}
+	return strings.HasPrefix(lines[idx], "  - ") && inItemsBlock(lines, idx)
+}
+
+func inItemsBlock(lines []string, idx int) bool {
+	for j := idx - 1; j >= 0; j-- {
+		trim := strings.TrimSpace(lines[j])
+		if strings.HasPrefix(lines[j], "  - ") {
+			continue
+		}
+		return trim == "- items:"
+	}
+	return false
+}
+
+func parseReview(lines []string) SectionReview {
+	review := SectionReview{}
+	inItems := false
+	for _, line := range lines {
+		trim := strings.TrimSpace(line)
+		if strings.HasPrefix(trim, "- suggestion:") || strings.HasPrefix(trim, "## ") || strings.HasPrefix(trim, "```") || strings.HasPrefix(trim, "~~~") {
+			break
+		}
+		switch {
+		case strings.HasPrefix(trim, "- status:"):
+			review.Status = ReviewStatus(unquoteValue(strings.TrimPrefix(trim, "- status:")))
+			inItems = false
+		case strings.HasPrefix(trim, "- reviewed_by:"):
+			review.ReviewedBy = unquoteValue(strings.TrimPrefix(trim, "- reviewed_by:"))
+			inItems = false
+		case strings.HasPrefix(trim, "- reviewed_at:"):
+			review.ReviewedAt = unquoteValue(strings.TrimPrefix(trim, "- reviewed_at:"))
+			inItems = false
+		case strings.HasPrefix(trim, "- reason:"):
+			review.Reason = unquoteValue(strings.TrimPrefix(trim, "- reason:"))
+			inItems = false
+		case trim == "- items:":
+			inItems = true
+			review.Items = make(map[string]ReviewStatus)
+		case inItems && strings.HasPrefix(line, "  - "):
+			entry := strings.TrimPrefix(trim, "- ")
+			idx := strings.LastIndex(entry, ":")
+			if idx < 0 {
+				continue
+			}
+			review.Items[unquoteValue(entry[:idx])] = ReviewStatus(unquoteValue(entry[idx+1:]))
+		}
+	}
+	return review
+}
+
+func combineStatuses(items map[string]ReviewStatus) ReviewStatus {
+	accepted, rejected := 0, 0
+	for _, status := range items {
+		switch status {
+		case StatusAccepted:
+			accepted++
+		case StatusRejected:
+			rejected++
+		}
+	}
+	switch {
+	case accepted > 0 && rejected == 0:
+		return StatusAccepted
+	case rejected > 0 && accepted == 0:
+		return StatusRejected
+	default:
+		return StatusPartial
+	}
+}
+
+func reviewItemKey(item Item) string {
+	if item.ID != "" {
+		return item.ID
+	}
+	return fmt.Sprintf("#%d", item.Index+1)
+}
+
+func unquoteValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
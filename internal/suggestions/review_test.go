@@ -0,0 +1,158 @@
+package suggestions
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mistakeknot/praude/internal/specs"
+)
+
+func TestRecordReviewPersistsSectionAndItemStatus(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "PRD-001-20260115-000000.md")
+	body := `# Suggestions for PRD-001
+
+## Summary
+- status: pending
+- suggestion: "New summary"
+
+## Requirements
+- status: pending
+- suggestion:
+  - "REQ-001: One"
+  - "REQ-002: Two"
+`
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sugg, _, err := LoadLatest(dir, "PRD-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sugg.Status() != StatusPending {
+		t.Fatalf("expected pending, got %s", sugg.Status())
+	}
+	offered := Items(sugg, specs.Spec{})
+	keep := func(item Item) bool { return item.ID != "REQ-002" }
+	now := time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC)
+	if err := RecordReview(path, offered, keep, "alice", now); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordReview(path, offered, keep, "alice", now); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(raw), "- status:") != 2 {
+		t.Fatalf("expected status lines replaced, got %q", string(raw))
+	}
+	sugg, _, err = LoadLatest(dir, "PRD-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sugg.Summary != "New summary" || len(sugg.Requirements) != 2 {
+		t.Fatalf("expected suggestion content intact, got %+v", sugg)
+	}
+	review := sugg.Reviews[SectionRequirements]
+	if review.Status != StatusPartial || review.ReviewedBy != "alice" || review.ReviewedAt != "2026-01-16T09:00:00Z" {
+		t.Fatalf("unexpected requirements review: %+v", review)
+	}
+	if review.Items["REQ-001"] != StatusAccepted || review.Items["REQ-002"] != StatusRejected {
+		t.Fatalf("unexpected item statuses: %+v", review.Items)
+	}
+	if sugg.Reviews[SectionSummary].Status != StatusAccepted {
+		t.Fatalf("expected summary accepted")
+	}
+	if sugg.Status() != StatusPartial {
+		t.Fatalf("expected partial file status, got %s", sugg.Status())
+	}
+}
+
+func TestRecordReviewMergesWithEarlierDecisions(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "PRD-001-20260115-000000.md")
+	body := `# Suggestions for PRD-001
+
+## Requirements
+- status: pending
+- suggestion:
+  - "REQ-001: One"
+  - "REQ-002: Two"
+`
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sugg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	offered := Items(sugg, specs.Spec{})
+	first := time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC)
+	if err := RecordReview(path, offered[:1], func(Item) bool { return true }, "alice", first); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordReview(path, offered[1:], func(Item) bool { return false }, "bob", first.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	sugg, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	review := sugg.Reviews[SectionRequirements]
+	if review.Items["REQ-001"] != StatusAccepted || review.Items["REQ-002"] != StatusRejected {
+		t.Fatalf("expected both decisions kept, got %+v", review.Items)
+	}
+	if review.Status != StatusPartial || review.ReviewedBy != "alice, bob" {
+		t.Fatalf("unexpected merged review: %+v", review)
+	}
+}
+
+func TestRecordRejectionStoresReasons(t *testing.T) {
+	dir := t.TempDir()
+	spec := specs.Spec{ID: "PRD-001", Title: "Title", Summary: "Old"}
+	parent, err := CreateFromSpec(dir, spec, "codex", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(parent, []byte(strings.Replace(string(raw), `# summary: ""`, `summary: "Vague"`, 1)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sugg, err := Load(parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reasons := map[string]string{SectionSummary: "too vague"}
+	if err := RecordRejection(parent, Items(sugg, spec), reasons, "alice", time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordRejection(parent, Items(sugg, spec), reasons, "alice", time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	file := Open(parent)
+	if file.Err != nil {
+		t.Fatal(file.Err)
+	}
+	review := file.Suggestion.Reviews[SectionSummary]
+	if review.Status != StatusRejected || review.Reason != "too vague" {
+		t.Fatalf("unexpected review: %+v", review)
+	}
+	child, err := CreateFollowUp(dir, spec, "codex", filepath.Base(parent), time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if child == parent {
+		t.Fatalf("follow-up must not overwrite %s", parent)
+	}
+	if got := Open(child).Suggestion.Parent; got != filepath.Base(parent) {
+		t.Fatalf("expected parent link, got %q", got)
+	}
+}
@@ -0,0 +1,396 @@
+package suggestions
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/mistakeknot/praude/internal/specs"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	SectionSpecTitle    = "title"
+	SectionContext      = "strategic_context"
+	SectionUserStory    = "user_story"
+	SectionSummary      = "summary"
+	SectionRequirements = "requirements"
+	SectionAcceptance   = "acceptance_criteria"
+	SectionFiles        = "files_to_modify"
+	SectionCUJ          = "critical_user_journeys"
+	SectionMarket       = "market_research"
+	SectionCompetitive  = "competitive_landscape"
+	SectionOSS          = "oss_landscape"
+	SectionComplexity   = "complexity"
+	SectionEstimate     = "estimated_minutes"
+	SectionPriority     = "priority"
+)
+
+type section struct {
+	key      string
+	title    string
+	label    string
+	single   bool
+	example  string
+	items    func(s Suggestion, spec specs.Spec) []Item
+	keep     func(s *Suggestion, keep func(index int) bool)
+	merge    func(spec *specs.Spec, s Suggestion)
+	clash    func(base, current specs.Spec, s Suggestion) []int
+	mismatch func(current specs.Spec, s Suggestion) map[int]string
+	decode   func(s *Suggestion, node *yaml.Node) error
+}
+
+var sections = []section{
+	valueSection(SectionSpecTitle, "Title", "Title",
+		func(s *Suggestion) *string { return &s.Title },
+		func(spec *specs.Spec) *string { return &spec.Title },
+		func(title string) string { return title },
+		`title: ""`,
+	),
+	valueSection(SectionContext, "Strategic Context", "Context",
+		func(s *Suggestion) *specs.StrategicContext { return &s.StrategicContext },
+		func(spec *specs.Spec) *specs.StrategicContext { return &spec.StrategicContext },
+		func(ctx specs.StrategicContext) string {
+			mvp := "(not in MVP)"
+			if ctx.MVPIncluded {
+				mvp = "(in MVP)"
+			}
+			return strings.TrimSpace(joinLabel(ctx.CUJID, ctx.CUJName) + " " + ctx.FeatureID + " " + mvp)
+		},
+		`strategic_context:
+  cuj_id: ""
+  cuj_name: ""
+  feature_id: ""
+  mvp_included: false`,
+	),
+	valueSection(SectionUserStory, "User Story", "Story",
+		func(s *Suggestion) *specs.UserStory { return &s.UserStory },
+		func(spec *specs.Spec) *specs.UserStory { return &spec.UserStory },
+		func(story specs.UserStory) string { return story.Text },
+		`user_story:
+  text: ""`,
+	),
+	valueSection(SectionSummary, "Summary", "Summary",
+		func(s *Suggestion) *string { return &s.Summary },
+		func(spec *specs.Spec) *string { return &spec.Summary },
+		func(summary string) string { return summary },
+		`summary: ""`,
+	),
+	listSection(SectionRequirements, "Requirements", "Requirements",
+		func(s *Suggestion) *[]Change[string] { return &s.Requirements },
+		func(spec *specs.Spec) *[]string { return &spec.Requirements },
+		specs.RequirementID,
+		func(req string) string { return req },
+		`requirements:
+  - "REQ-001: Add requirement"`,
+	),
+	listSection(SectionAcceptance, "Acceptance Criteria", "Acceptance",
+		func(s *Suggestion) *[]Change[specs.AcceptanceCriterion] { return &s.Acceptance },
+		func(spec *specs.Spec) *[]specs.AcceptanceCriterion { return &spec.Acceptance },
+		func(item specs.AcceptanceCriterion) string { return item.ID },
+		func(item specs.AcceptanceCriterion) string { return joinLabel(item.ID, item.Description) },
+		`acceptance_criteria:
+  - id: "ac-1"
+    description: "Acceptance criterion"`,
+	),
+	listSection(SectionFiles, "Files to Modify", "Files",
+		func(s *Suggestion) *[]Change[specs.FileChange] { return &s.FilesToModify },
+		func(spec *specs.Spec) *[]specs.FileChange { return &spec.FilesToModify },
+		func(item specs.FileChange) string { return item.Path },
+		func(item specs.FileChange) string { return joinLabel(item.Path, item.Action) },
+		`files_to_modify:
+  - action: "modify"
+    path: "path/to/file"
+    description: "Why this file"`,
+	),
+	listSection(SectionCUJ, "Critical User Journeys", "CUJ",
+		func(s *Suggestion) *[]Change[specs.CriticalUserJourney] { return &s.CriticalUserJourneys },
+		func(spec *specs.Spec) *[]specs.CriticalUserJourney { return &spec.CriticalUserJourneys },
+		func(item specs.CriticalUserJourney) string { return item.ID },
+		func(item specs.CriticalUserJourney) string { return joinLabel(item.ID, item.Title) },
+		`critical_user_journeys:
+  - id: "CUJ-001"
+    title: "Primary Journey"
+    priority: "high"
+    steps:
+      - "Step"
+    success_criteria:
+      - "Outcome"
+    linked_requirements:
+      - "REQ-001"`,
+	),
+	listSection(SectionMarket, "Market Research", "Market",
+		func(s *Suggestion) *[]Change[specs.MarketResearchItem] { return &s.MarketResearch },
+		func(spec *specs.Spec) *[]specs.MarketResearchItem { return &spec.MarketResearch },
+		func(item specs.MarketResearchItem) string { return item.ID },
+		func(item specs.MarketResearchItem) string { return joinLabel(item.ID, item.Claim) },
+		`market_research:
+  - id: "MR-001"
+    claim: "Market claim"
+    evidence_refs:
+      - path: ".praude/research/{id}-YYYYMMDD-HHMMSS.md"
+        anchor: "section-1"
+        note: "Source quote"
+    confidence: "medium"
+    date: "2026-01-15"`,
+	),
+	listSection(SectionCompetitive, "Competitive Landscape", "Competitive",
+		func(s *Suggestion) *[]Change[specs.CompetitiveLandscapeItem] { return &s.CompetitiveLandscape },
+		func(spec *specs.Spec) *[]specs.CompetitiveLandscapeItem { return &spec.CompetitiveLandscape },
+		func(item specs.CompetitiveLandscapeItem) string { return item.ID },
+		func(item specs.CompetitiveLandscapeItem) string { return joinLabel(item.ID, item.Name) },
+		`competitive_landscape:
+  - id: "COMP-001"
+    name: "Competitor"
+    positioning: "Positioning"
+    strengths:
+      - "Strength"
+    weaknesses:
+      - "Weakness"
+    risk: "Medium"
+    evidence_refs:
+      - path: ".praude/research/{id}-YYYYMMDD-HHMMSS.md"
+        anchor: "section-2"
+        note: "Source quote"`,
+	),
+	listSection(SectionOSS, "OSS Landscape", "OSS",
+		func(s *Suggestion) *[]Change[specs.OSSLandscapeItem] { return &s.OSSLandscape },
+		func(spec *specs.Spec) *[]specs.OSSLandscapeItem { return &spec.OSSLandscape },
+		func(item specs.OSSLandscapeItem) string { return item.ID },
+		func(item specs.OSSLandscapeItem) string { return joinLabel(item.ID, item.Name) },
+		`oss_landscape:
+  - id: "OSS-001"
+    name: "Project"
+    repo: "https://github.com/example/project"
+    license: "MIT"
+    learnings:
+      - "Learning"
+    insights:
+      - "Insight"
+    reusable_components:
+      - "Component"
+    evidence_refs:
+      - path: ".praude/research/{id}-YYYYMMDD-HHMMSS.md"
+        anchor: "section-3"
+        note: "Source quote"`,
+	),
+	valueSection(SectionComplexity, "Complexity", "Complexity",
+		func(s *Suggestion) *string { return &s.Complexity },
+		func(spec *specs.Spec) *string { return &spec.Complexity },
+		func(complexity string) string { return complexity },
+		`complexity: ""`,
+	),
+	valueSection(SectionEstimate, "Estimated Minutes", "Estimate",
+		func(s *Suggestion) *int { return &s.EstimatedMinutes },
+		func(spec *specs.Spec) *int { return &spec.EstimatedMinutes },
+		func(minutes int) string { return strconv.Itoa(minutes) + " minutes" },
+		`estimated_minutes: 0`,
+	),
+	valueSection(SectionPriority, "Priority", "Priority",
+		func(s *Suggestion) *int { return &s.Priority },
+		func(spec *specs.Spec) *int { return &spec.Priority },
+		func(priority int) string { return strconv.Itoa(priority) },
+		`priority: 0`,
+	),
+}
+
+func valueSection[T comparable](key, title, label string, suggested func(*Suggestion) *T, current func(*specs.Spec) *T, describe func(T) string, example string) section {
+	var zero T
+	return section{
+		key:     key,
+		title:   title,
+		label:   label,
+		single:  true,
+		example: example,
+		items: func(s Suggestion, spec specs.Spec) []Item {
+			value := *suggested(&s)
+			if (value == zero && !s.set[key]) || strings.TrimSpace(describe(value)) == "" {
+				return nil
+			}
+			return []Item{{Section: key, ID: key, Action: ActionUpdate, Label: describe(value)}}
+		},
+		keep: func(s *Suggestion, keep func(int) bool) {
+			if !keep(0) {
+				*suggested(s) = zero
+				s.mark(key, false)
+			}
+		},
+		merge: func(spec *specs.Spec, s Suggestion) {
+			if value := *suggested(&s); value != zero || s.set[key] {
+				*current(spec) = value
+			}
+		},
+		clash: func(base, cur specs.Spec, s Suggestion) []int {
+			value := *suggested(&s)
+			if (value != zero || s.set[key]) && *current(&base) != *current(&cur) && value != *current(&cur) {
+				return []int{0}
+			}
+			return nil
+		},
+		decode: func(s *Suggestion, node *yaml.Node) error {
+			if err := node.Decode(suggested(s)); err != nil {
+				return err
+			}
+			s.mark(key, true)
+			return nil
+		},
+	}
+}
+
+func listSection[T any](key, title, label string, changes func(*Suggestion) *[]Change[T], current func(*specs.Spec) *[]T, id func(T) string, describe func(T) string, example string) section {
+	return section{
+		key:     key,
+		title:   title,
+		label:   label,
+		example: example,
+		items: func(s Suggestion, spec specs.Spec) []Item {
+			existing := *current(&spec)
+			var out []Item
+			for i, change := range *changes(&s) {
+				itemID := id(change.Value)
+				action := change.Action
+				if action != ActionRemove {
+					action = ActionAdd
+					if specs.IndexByID(existing, itemID, id) >= 0 {
+						action = ActionUpdate
+					}
+				}
+				out = append(out, Item{Section: key, Index: i, ID: itemID, Action: action, Label: describe(change.Value)})
+			}
+			return out
+		},
+		keep: func(s *Suggestion, keep func(int) bool) {
+			list := changes(s)
+			var out []Change[T]
+			for i, change := range *list {
+				if keep(i) {
+					out = append(out, change)
+				}
+			}
+			*list = out
+		},
+		merge: func(spec *specs.Spec, s Suggestion) {
+			list := current(spec)
+			*list = mergeByID(*list, *changes(&s), id)
+		},
+		clash: func(base, cur specs.Spec, s Suggestion) []int {
+			baseItems, curItems := *current(&base), *current(&cur)
+			var out []int
+			for i, change := range *changes(&s) {
+				key := id(change.Value)
+				b, inBase := lookupByID(baseItems, key, id)
+				c, inCurrent := lookupByID(curItems, key, id)
+				if key == "" || (inBase == inCurrent && reflect.DeepEqual(b, c)) {
+					continue
+				}
+				theirs := inCurrent
+				if change.Action != ActionRemove {
+					theirs = !inCurrent || !reflect.DeepEqual(change.Value, c)
+				}
+				if theirs {
+					out = append(out, i)
+				}
+			}
+			return out
+		},
+		mismatch: func(cur specs.Spec, s Suggestion) map[int]string {
+			existing := *current(&cur)
+			out := make(map[int]string)
+			for i, change := range *changes(&s) {
+				found := specs.IndexByID(existing, id(change.Value), id) >= 0
+				switch {
+				case change.Action == ActionAdd && found:
+					out[i] = ReasonAddExisting
+				case change.Action == ActionUpdate && !found:
+					out[i] = ReasonUpdateMissing
+				}
+			}
+			return out
+		},
+		decode: func(s *Suggestion, node *yaml.Node) error {
+			return node.Decode(changes(s))
+		},
+	}
+}
+
+func SectionKeys() []string {
+	keys := make([]string, 0, len(sections))
+	for _, sec := range sections {
+		keys = append(keys, sec.key)
+	}
+	return keys
+}
+
+func SectionTitle(key string) string {
+	for _, sec := range sections {
+		if sec.key == key {
+			return sec.title
+		}
+	}
+	return key
+}
+
+func SectionLabel(key string) string {
+	for _, sec := range sections {
+		if sec.key == key {
+			return sec.label
+		}
+	}
+	return key
+}
+
+func SectionCount(s Suggestion, key string) string {
+	count := 0
+	single := false
+	for _, sec := range sections {
+		if sec.key == key {
+			count = len(sec.items(s, specs.Spec{}))
+			single = sec.single
+		}
+	}
+	if single {
+		if count > 0 {
+			return "yes"
+		}
+		return "no"
+	}
+	return strconv.Itoa(count)
+}
+
+func decodeSection(s *Suggestion, key string, node *yaml.Node) error {
+	for _, sec := range sections {
+		if sec.key == key {
+			return sec.decode(s, node)
+		}
+	}
+	return fmt.Errorf("unknown section %q", key)
+}
+
+func sectionKeyForTitle(title string) string {
+	for _, sec := range sections {
+		if sec.title == title {
+			return sec.key
+		}
+	}
+	return ""
+}
+
+func LookupSection(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	for _, sec := range sections {
+		if strings.EqualFold(name, sec.key) || strings.EqualFold(name, sec.title) || strings.EqualFold(name, sec.label) {
+			return sec.key, true
+		}
+	}
+	return "", false
+}
+
+func templateSections(id string) string {
+	var b strings.Builder
+	for _, sec := range sections {
+		example := strings.ReplaceAll(sec.example, "{id}", id)
+		fmt.Fprintf(&b, "\n## %s\n- status: pending\n\n~~~yaml\n# %s\n~~~\n", sec.title, strings.ReplaceAll(example, "\n", "\n# "))
+	}
+	return b.String()
+}
@@ -7,16 +7,44 @@ import (
 	"strings"
 	"time"
 
+	"github.com/mistakeknot/praude/internal/project"
 	"github.com/mistakeknot/praude/internal/specs"
 	"gopkg.in/yaml.v3"
 )
 
 type Suggestion struct {
+	Title                string
+	StrategicContext     specs.StrategicContext
+	UserStory            specs.UserStory
 	Summary              string
-	Requirements         []string
-	CriticalUserJourneys []specs.CriticalUserJourney
-	MarketResearch       []specs.MarketResearchItem
-	CompetitiveLandscape []specs.CompetitiveLandscapeItem
+	Requirements         []Change[string]
+	Acceptance           []Change[specs.AcceptanceCriterion]
+	FilesToModify        []Change[specs.FileChange]
+	CriticalUserJourneys []Change[specs.CriticalUserJourney]
+	MarketResearch       []Change[specs.MarketResearchItem]
+	CompetitiveLandscape []Change[specs.CompetitiveLandscapeItem]
+	OSSLandscape         []Change[specs.OSSLandscapeItem]
+	Complexity           string
+	EstimatedMinutes     int
+	Priority             int
+	Reviews              map[string]SectionReview
+	BaseHash             string
+	Agent                string
+	Parent               string
+	set                  map[string]bool
+}
+
+func (s *Suggestion) mark(key string, on bool) {
+	set := make(map[string]bool, len(s.set)+1)
+	for k := range s.set {
+		set[k] = true
+	}
+	if on {
+		set[key] = true
+	} else {
+		delete(set, key)
+	}
+	s.set = set
 }
 
 func LoadLatest(dir, id string) (Suggestion, string, error) {
@@ -42,126 +70,135 @@ func LoadLatest(dir, id string) (Suggestion, string, error) {
 	if latestPath == "" {
 		return Suggestion{}, "", fmt.Errorf("no suggestions for %s", id)
 	}
-	raw, err := os.ReadFile(latestPath)
+	sugg, err := Load(latestPath)
 	if err != nil {
-		return Suggestion{}, "", err
+		return Suggestion{}, latestPath, err
+	}
+	return sugg, latestPath, nil
+}
+
+func Load(path string) (Suggestion, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return Suggestion{}, err
+	}
+	sugg, err := parseSuggestion(raw)
+	if err != nil {
+		return Suggestion{}, fmt.Errorf("%s: %w", filepath.Base(path), err)
 	}
-	return parseSuggestion(raw), latestPath, nil
+	return sugg, nil
 }
 
 func Create(dir, id string, now time.Time) (string, error) {
-	name := fmt.Sprintf("%s-%s.md", id, now.UTC().Format("20060102-150405"))
-	path := filepath.Join(dir, name)
-	body := fmt.Sprintf(`# Suggestions for %s
+	return create(dir, id, now, "", "", "")
+}
 
-## Summary
-- status: pending
-- suggestion: ""
+func CreateFromSpec(dir string, spec specs.Spec, agent string, now time.Time) (string, error) {
+	return CreateFollowUp(dir, spec, agent, "", now)
+}
 
-## Requirements
-- status: pending
-- suggestion:
-  - "REQ-001: Add requirement"
+func CreateFollowUp(dir string, spec specs.Spec, agent, parent string, now time.Time) (string, error) {
+	hash := specs.SpecHash(spec)
+	if err := os.MkdirAll(baseDir(dir), 0o755); err != nil {
+		return "", err
+	}
+	if err := specs.SaveSpec(basePath(dir, hash), spec); err != nil {
+		return "", err
+	}
+	return create(dir, spec.ID, now, hash, agent, parent)
+}
 
-## Critical User Journeys
-- status: pending
-- suggestion:
-  - id: "CUJ-001"
-    title: "Primary Journey"
-    priority: "high"
-    steps:
-      - "Step"
-    success_criteria:
-      - "Outcome"
-    linked_requirements:
-      - "REQ-001"
+func LoadBase(dir, hash string) (specs.Spec, error) {
+	if hash == "" {
+		return specs.Spec{}, fmt.Errorf("suggestion has no base hash")
+	}
+	return specs.LoadSpec(basePath(dir, hash))
+}
 
-## Market Research
-- status: pending
-- suggestion:
-  - id: "MR-001"
-    claim: "Market claim"
-    evidence_refs:
-      - path: ".praude/research/%s-YYYYMMDD-HHMMSS.md"
-        anchor: "section-1"
-        note: "Source quote"
-    confidence: "medium"
-    date: "2026-01-15"
+func baseDir(dir string) string {
+	return filepath.Join(dir, "base")
+}
 
-## Competitive Landscape
-- status: pending
-- suggestion:
-  - id: "COMP-001"
-    name: "Competitor"
-    positioning: "Positioning"
-    strengths:
-      - "Strength"
-    weaknesses:
-      - "Weakness"
-    risk: "Medium"
-    evidence_refs:
-      - path: ".praude/research/%s-YYYYMMDD-HHMMSS.md"
-        anchor: "section-2"
-        note: "Source quote"
-`, id, id, id)
-	return path, os.WriteFile(path, []byte(body), 0o644)
+func basePath(dir, hash string) string {
+	return filepath.Join(baseDir(dir), hash+".yaml")
 }
 
-func Apply(path string, suggestion Suggestion) error {
-	raw, err := os.ReadFile(path)
-	if err != nil {
-		return err
+func create(dir, id string, now time.Time, baseHash, agent, parent string) (string, error) {
+	path := project.StampedPath(dir, id, now)
+	front := fmt.Sprintf("format: %d\nid: %q\n", FormatVersion, id)
+	if baseHash != "" {
+		front += fmt.Sprintf("base_hash: %q\n", baseHash)
 	}
-	var doc specs.Spec
-	if err := yaml.Unmarshal(raw, &doc); err != nil {
-		return err
+	if agent != "" {
+		front += fmt.Sprintf("agent: %q\n", agent)
 	}
-	if suggestion.Summary != "" {
-		doc.Summary = suggestion.Summary
+	if parent != "" {
+		front += fmt.Sprintf("parent: %q\n", parent)
 	}
-	if len(suggestion.Requirements) > 0 {
-		doc.Requirements = suggestion.Requirements
-	}
-	if len(suggestion.CriticalUserJourneys) > 0 {
-		doc.CriticalUserJourneys = suggestion.CriticalUserJourneys
-	}
-	if len(suggestion.MarketResearch) > 0 {
-		doc.MarketResearch = suggestion.MarketResearch
-	}
-	if len(suggestion.CompetitiveLandscape) > 0 {
-		doc.CompetitiveLandscape = suggestion.CompetitiveLandscape
-	}
-	updated, err := yaml.Marshal(&doc)
-	if err != nil {
-		return err
-	}
-	return os.WriteFile(path, updated, 0o644)
+	body := fmt.Sprintf(`---
+%s---
+# Suggestions for %s
+
+Each section holds one fenced YAML block keyed by the section name. Examples are commented out; uncomment and edit one to suggest a change, or leave the block as is to suggest nothing. List items are merged into the spec by ID. Add `+"`change: \"remove\"`"+` to an item to drop it.
+`, front, id) + templateSections(id)
+	return path, os.WriteFile(path, []byte(body), 0o644)
 }
 
-func parseSuggestion(raw []byte) Suggestion {
+func parseSuggestion(raw []byte) (Suggestion, error) {
 	lines := strings.Split(string(raw), "\n")
-	out := Suggestion{}
+	if hasFrontMatter(lines) {
+		return parseV2(lines)
+	}
+	return parseV1(lines)
+}
+
+func parseV1(lines []string) (Suggestion, error) {
+	out := Suggestion{Reviews: make(map[string]SectionReview)}
 	for i := 0; i < len(lines); i++ {
 		line := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(line, "- base_hash:") && len(out.Reviews) == 0 {
+			out.BaseHash = unquoteValue(strings.TrimPrefix(line, "- base_hash:"))
+			continue
+		}
 		if !strings.HasPrefix(line, "## ") {
 			continue
 		}
-		section := strings.TrimSpace(strings.TrimPrefix(line, "## "))
-		block := extractSuggestionBlock(lines, i+1)
-		switch section {
-		case "Summary":
-			out.Summary = parseSummaryBlock(block)
-		case "Requirements":
-			out.Requirements = parseStringListBlock(block)
-		case "Critical User Journeys":
-			out.CriticalUserJourneys = parseCUJBlock(block)
-		case "Market Research":
-			out.MarketResearch = parseMarketBlock(block)
-		case "Competitive Landscape":
-			out.CompetitiveLandscape = parseCompetitiveBlock(block)
+		key := sectionKeyForTitle(strings.TrimSpace(strings.TrimPrefix(line, "## ")))
+		if key == "" {
+			continue
+		}
+		out.Reviews[key] = parseReview(lines[i+1:])
+		block, start := extractSuggestionBlock(lines, i+1)
+		if err := decodeV1Block(&out, key, block, start); err != nil {
+			return Suggestion{}, err
 		}
 	}
-	return out
+	return out, nil
+}
+
+func decodeV1Block(out *Suggestion, key string, block []string, start int) error {
+	if len(block) == 0 {
+		return nil
+	}
+	if key == SectionSummary {
+		out.Summary = parseSummaryBlock(block)
+		return nil
+	}
+	if inline := betweenQuotes(block[0]); inline != "" && key == SectionRequirements {
+		out.Requirements = []Change[string]{{Value: inline}}
+		return nil
+	}
+	var wrapper struct {
+		Items yaml.Node `yaml:"items"`
+	}
+	doc := "items:\n" + strings.Join(block[1:], "\n")
+	if err := yaml.Unmarshal([]byte(doc), &wrapper); err != nil {
+		return shiftLines(err, start)
+	}
+	if wrapper.Items.Kind == 0 || wrapper.Items.Tag == "!!null" {
+		return nil
+	}
+	return shiftLines(decodeSection(out, key, &wrapper.Items), start)
 }
 
 func firstQuoted(lines []string) string {
@@ -189,11 +226,11 @@ func betweenQuotes(line string) string {
 	return ""
 }
 
-func extractSuggestionBlock(lines []string, start int) []string {
+func extractSuggestionBlock(lines []string, start int) ([]string, int) {
 	for i := start; i < len(lines); i++ {
 		trim := strings.TrimSpace(lines[i])
 		if strings.HasPrefix(trim, "## ") {
-			return nil
+			return nil, i
 		}
 		if strings.HasPrefix(trim, "- suggestion:") {
 			block := []string{lines[i]}
@@ -204,10 +241,10 @@ func extractSuggestionBlock(lines []string, start int) []string {
 				}
 				block = append(block, lines[j])
 			}
-			return block
+			return block, i
 		}
 	}
-	return nil
+	return nil, start
 }
 
 func parseSummaryBlock(block []string) string {
@@ -219,69 +256,3 @@ func parseSummaryBlock(block []string) string {
 	}
 	return firstQuoted(block[1:])
 }
-
-func parseStringListBlock(block []string) []string {
-	if len(block) == 0 {
-		return nil
-	}
-	if inline := betweenQuotes(block[0]); inline != "" {
-		return []string{inline}
-	}
-	var wrapper struct {
-		Items []string `yaml:"items"`
-	}
-	if !parseBlock(block[1:], &wrapper) {
-		return nil
-	}
-	return wrapper.Items
-}
-
-func parseCUJBlock(block []string) []specs.CriticalUserJourney {
-	var wrapper struct {
-		Items []specs.CriticalUserJourney `yaml:"items"`
-	}
-	if !parseBlock(block[1:], &wrapper) {
-		return nil
-	}
-	return wrapper.Items
-}
-
-func parseMarketBlock(block []string) []specs.MarketResearchItem {
-	var wrapper struct {
-		Items []specs.MarketResearchItem `yaml:"items"`
-	}
-	if !parseBlock(block[1:], &wrapper) {
-		return nil
-	}
-	return wrapper.Items
-}
-
-func parseCompetitiveBlock(block []string) []specs.CompetitiveLandscapeItem {
-	var wrapper struct {
-		Items []specs.CompetitiveLandscapeItem `yaml:"items"`
-	}
-	if !parseBlock(block[1:], &wrapper) {
-		return nil
-	}
-	return wrapper.Items
-}
-
-func parseBlock(lines []string, out interface{}) bool {
-	if len(lines) == 0 {
-		return false
-	}
-	var b strings.Builder
-	b.WriteString("items:\n")
-	for _, line := range lines {
-		trim := strings.TrimSpace(line)
-		if trim == "" {
-			continue
-		}
-		b.WriteString(line)
-		b.WriteString("\n")
-	}
-	if err := yaml.Unmarshal([]byte(b.String()), out); err != nil {
-		return false
-	}
-	return true
-}
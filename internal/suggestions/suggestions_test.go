@@ -1,6 +1,7 @@
 package suggestions
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -40,7 +41,7 @@ func TestApplySuggestionUpdatesSummary(t *testing.T) {
 		t.Fatal(err)
 	}
 	sugg := Suggestion{Summary: "New summary"}
-	if err := Apply(specPath, sugg); err != nil {
+	if _, err := Apply(specPath, sugg, specs.ValidationOptions{}); err != nil {
 		t.Fatal(err)
 	}
 	updated, err := os.ReadFile(specPath)
@@ -69,7 +70,7 @@ func TestLoadLatestSuggestionParsesSummary(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	updated := strings.Replace(string(raw), "suggestion: \"\"", "suggestion: \"Updated summary\"", 1)
+	updated := strings.Replace(string(raw), "# summary: \"\"", "summary: \"Updated summary\"", 1)
 	if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
 		t.Fatal(err)
 	}
@@ -91,7 +92,13 @@ func TestLoadLatestSuggestionParsesRequirementsAndCUJ(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	_ = path
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(strings.ReplaceAll(string(raw), "\n# ", "\n")), 0o644); err != nil {
+		t.Fatal(err)
+	}
 	sugg, latest, err := LoadLatest(dir, "PRD-001")
 	if err != nil {
 		t.Fatal(err)
@@ -105,4 +112,69 @@ func TestLoadLatestSuggestionParsesRequirementsAndCUJ(t *testing.T) {
 	if len(sugg.CriticalUserJourneys) == 0 {
 		t.Fatalf("expected cuj parsed")
 	}
+	if len(sugg.OSSLandscape) == 0 || sugg.OSSLandscape[0].Value.ID != "OSS-001" {
+		t.Fatalf("expected oss landscape parsed")
+	}
+}
+
+func TestApplyRefusesInvalidSpecInHardMode(t *testing.T) {
+	root := t.TempDir()
+	specPath := filepath.Join(root, "PRD-001.yaml")
+	specRaw := []byte("id: \"PRD-001\"\ntitle: \"Title\"\nsummary: \"Old\"\nrequirements:\n  - \"REQ-001: Do it\"\n")
+	if err := os.WriteFile(specPath, specRaw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sugg := Suggestion{CriticalUserJourneys: []Change[specs.CriticalUserJourney]{{Value: specs.CriticalUserJourney{
+		ID:                 "CUJ-001",
+		Title:              "Flow",
+		Priority:           "high",
+		LinkedRequirements: []string{"REQ-404"},
+	}}}}
+	delta, err := Apply(specPath, sugg, specs.ValidationOptions{Mode: specs.ValidationHard, Root: root})
+	var verr ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if len(verr.Errors) != 1 || !strings.Contains(verr.Errors[0], "REQ-404") {
+		t.Fatalf("unexpected errors: %v", verr.Errors)
+	}
+	if len(delta.New) == 0 || delta.New[0] != "cuj linked requirement not found: REQ-404" {
+		t.Fatalf("unexpected delta: %+v", delta)
+	}
+	updated, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(updated) != string(specRaw) {
+		t.Fatalf("spec should be unchanged")
+	}
+}
+
+func TestApplyStoresWarningsInSoftMode(t *testing.T) {
+	root := t.TempDir()
+	specPath := filepath.Join(root, "PRD-001.yaml")
+	specRaw := []byte("id: \"PRD-001\"\ntitle: \"Title\"\nsummary: \"Old\"\n")
+	if err := os.WriteFile(specPath, specRaw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sugg := Suggestion{CriticalUserJourneys: []Change[specs.CriticalUserJourney]{{Value: specs.CriticalUserJourney{
+		ID:                 "CUJ-001",
+		Title:              "Flow",
+		Priority:           "high",
+		LinkedRequirements: []string{"REQ-404"},
+	}}}}
+	delta, err := Apply(specPath, sugg, specs.ValidationOptions{Mode: specs.ValidationSoft, Root: root})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(delta.New) != 1 || len(delta.Resolved) != 0 {
+		t.Fatalf("unexpected delta: %+v", delta)
+	}
+	updated, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(updated), "validation_warnings") || !strings.Contains(string(updated), "REQ-404") {
+		t.Fatalf("expected stored warnings, got:\n%s", updated)
+	}
 }
@@ -1,21 +1,104 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 
-	"github.com/mistakeknot/praude/internal/agents"
 	"github.com/mistakeknot/praude/internal/config"
+	"github.com/mistakeknot/praude/internal/runs"
 )
 
-var launchAgent = agents.Launch
-var launchSubagent = agents.LaunchSubagent
+var (
+	launchRun  = runs.Launch
+	prepareRun = runs.Prepare
+)
+
+type runAttachedMsg struct {
+	err error
+}
 
-func agentProfiles(cfg config.Config) map[string]agents.Profile {
-	out := make(map[string]agents.Profile)
-	for name, profile := range cfg.Agents {
-		out[name] = agents.Profile{Command: profile.Command, Args: profile.Args}
+func (m *Model) startRun(req runs.Request) (runs.Run, error) {
+	var run runs.Run
+	var err error
+	if req.Profile.Interactive() {
+		run, err = m.attachRun(req)
+	} else {
+		run, err = launchRun(m.root, req)
 	}
-	return out
+	if err == nil && run.ID != "" {
+		if m.watching == nil {
+			m.watching = make(map[string]string)
+		}
+		m.watching[run.ID] = run.IngestHash
+		if run.Active() {
+			m.active = append(m.active, run)
+		}
+	}
+	return run, err
+}
+
+func (m *Model) attachRun(req runs.Request) (runs.Run, error) {
+	run, err := prepareRun(m.root, req)
+	if err != nil {
+		return run, err
+	}
+	cmd, err := runs.SuperviseCommand(m.root, run.ID)
+	if err != nil {
+		return run, err
+	}
+	m.attach = cmd
+	return run, nil
+}
+
+func (m *Model) checkRuns() {
+	if m.root == "" {
+		return
+	}
+	all, err := runs.List(m.root)
+	if err != nil {
+		return
+	}
+	active := make(map[string]string)
+	m.active = nil
+	for _, run := range all {
+		seen, watched := m.watching[run.ID]
+		if run.Active() {
+			active[run.ID] = run.IngestHash
+			m.active = append(m.active, run)
+		}
+		if !watched {
+			continue
+		}
+		status := ""
+		if run.Ingest != nil && run.IngestHash != seen {
+			status = run.Ingest.Message()
+		}
+		if run.Status == runs.StatusFailed {
+			status = runFailureStatus(run)
+		}
+		if !run.Active() && len(run.Discarded) > 0 {
+			status = strings.TrimSpace(status + fmt.Sprintf(" [discarded %d change(s) outside the artifact; praude runs show %s]", len(run.Discarded), run.ID))
+		}
+		if status != "" {
+			m.status = status
+		}
+	}
+	m.watching = active
+}
+
+func runFailureStatus(run runs.Run) string {
+	status := "Run " + run.ID + " failed"
+	if run.ExitCode != nil {
+		status += fmt.Sprintf(" (exit %d)", *run.ExitCode)
+	}
+	lines, _ := runs.Tail(run, 3)
+	if len(lines) == 0 && run.Error != "" {
+		lines = []string{run.Error}
+	}
+	if len(lines) > 0 {
+		status += ": " + strings.Join(lines, " | ")
+	}
+	return status
 }
 
 func defaultAgentName(cfg config.Config) string {
@@ -27,10 +110,3 @@ func defaultAgentName(cfg config.Config) string {
 	}
 	return "codex"
 }
-
-func isClaudeProfile(name string, profile agents.Profile) bool {
-	if strings.EqualFold(name, "claude") {
-		return true
-	}
-	return strings.Contains(strings.ToLower(profile.Command), "claude")
-}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/mistakeknot/praude/internal/config"
 	"github.com/mistakeknot/praude/internal/project"
 	"github.com/mistakeknot/praude/internal/research"
 	"github.com/mistakeknot/praude/internal/scan"
@@ -132,8 +133,7 @@ func (m *Model) runResearch() {
 	if m.interview.specID == "" {
 		return
 	}
-	researchDir := project.ResearchDir(m.interview.root)
-	_, _ = research.Create(researchDir, m.interview.specID, time.Now())
+	_, _ = research.CreateForSpec(m.interview.root, m.interview.specID, time.Now())
 }
 
 func (m *Model) exitInterview() {
@@ -243,7 +243,9 @@ func writeSpec(root string, spec specs.Spec) (string, string, []string) {
 	if err := osWriteFile(path, raw, 0o644); err != nil {
 		return path, id, nil
 	}
-	res, err := specs.Validate(raw, specs.ValidationOptions{Mode: specs.ValidationSoft, Root: root})
+	opts := config.LoadValidationOptions(root)
+	opts.Mode = specs.ValidationSoft
+	res, err := specs.Validate(raw, opts)
 	if err != nil {
 		return path, id, nil
 	}
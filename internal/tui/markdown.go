@@ -74,6 +74,9 @@ func detailMarkdown(spec specs.Spec) string {
 	b.WriteString("\n## Research\n- ")
 	b.WriteString(formatResearchDetail(spec))
 	b.WriteString("\n")
+	b.WriteString("\n## OSS\n- ")
+	b.WriteString(formatOSSDetail(spec))
+	b.WriteString("\n")
 	if len(spec.Metadata.ValidationWarnings) > 0 {
 		b.WriteString("\n## Validation Warnings\n")
 		for _, warning := range spec.Metadata.ValidationWarnings {
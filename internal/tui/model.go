@@ -3,14 +3,17 @@ package tui
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/mistakeknot/praude/internal/agents"
+	"github.com/mistakeknot/praude/internal/brief"
 	"github.com/mistakeknot/praude/internal/config"
 	"github.com/mistakeknot/praude/internal/project"
 	"github.com/mistakeknot/praude/internal/research"
+	"github.com/mistakeknot/praude/internal/runs"
 	"github.com/mistakeknot/praude/internal/specs"
 	"github.com/mistakeknot/praude/internal/suggestions"
 )
@@ -31,6 +34,9 @@ type Model struct {
 	interview   interviewState
 	suggestions suggestionsState
 	input       string
+	watching    map[string]string
+	active      []runs.Run
+	attach      *exec.Cmd
 }
 
 func NewModel() Model {
@@ -42,13 +48,41 @@ func NewModel() Model {
 		return Model{err: "Not initialized", root: cwd, mode: "list", router: Router{active: "list"}, width: 120, mdCache: NewMarkdownCache(), focus: "LIST"}
 	}
 	list, _ := specs.LoadSummaries(project.SpecsDir(cwd))
-	return Model{summaries: list, root: cwd, mode: "list", router: Router{active: "list"}, width: 120, mdCache: NewMarkdownCache(), focus: "LIST"}
+	m := Model{summaries: list, root: cwd, mode: "list", router: Router{active: "list"}, width: 120, mdCache: NewMarkdownCache(), focus: "LIST"}
+	m.checkRuns()
+	return m
 }
 
-func (m Model) Init() tea.Cmd { return nil }
+type runsTickMsg struct{}
+
+func tickRuns() tea.Cmd {
+	return tea.Tick(2*time.Second, func(time.Time) tea.Msg { return runsTickMsg{} })
+}
+
+func (m Model) Init() tea.Cmd { return tickRuns() }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.update(msg)
+	updated := next.(Model)
+	if updated.attach == nil {
+		return updated, cmd
+	}
+	attach := updated.attach
+	updated.attach = nil
+	return updated, tea.Batch(cmd, tea.ExecProcess(attach, func(err error) tea.Msg { return runAttachedMsg{err: err} }))
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case runsTickMsg:
+		m.checkRuns()
+		return m, tickRuns()
+	case runAttachedMsg:
+		m.checkRuns()
+		if msg.err != nil {
+			m.status = "Agent exited: " + msg.err.Error()
+		}
+		return m, nil
 	case tea.KeyMsg:
 		key := msg.String()
 		if msg.Type == tea.KeyEnter {
@@ -95,6 +129,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		}
+		if m.mode == "suggestions" && m.suggestions.picking {
+			switch key {
+			case "q", "ctrl+c":
+				m.mode = "list"
+			default:
+				m.handlePickerKey(key)
+			}
+			return m, nil
+		}
+		if m.mode == "suggestions" && m.suggestions.feedback {
+			m.handleFeedbackKey(key)
+			return m, nil
+		}
 		if m.mode == "suggestions" {
 			switch key {
 			case "q", "ctrl+c":
@@ -103,17 +150,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.applySuggestions()
 				m.mode = "list"
 			case "r":
-				m.mode = "list"
-			case "1":
-				m.suggestions.acceptSummary = !m.suggestions.acceptSummary
-			case "2":
-				m.suggestions.acceptRequirements = !m.suggestions.acceptRequirements
-			case "3":
-				m.suggestions.acceptCUJ = !m.suggestions.acceptCUJ
-			case "4":
-				m.suggestions.acceptMarket = !m.suggestions.acceptMarket
-			case "5":
-				m.suggestions.acceptCompetitive = !m.suggestions.acceptCompetitive
+				m.startRejection()
+			default:
+				m.handleSuggestionsKey(key)
 			}
 			return m, nil
 		}
@@ -151,6 +190,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.err == "" {
 				m.enterSuggestions()
 			}
+		case "u":
+			if m.err == "" {
+				m.undoSuggestionsForSelected()
+			}
 		case "j", "down":
 			if m.selected < len(m.summaries)-1 {
 				m.selected++
@@ -246,12 +289,25 @@ func (m Model) renderDetail() []string {
 			lines = append(lines, strings.Split(trimmed, "\n")...)
 		}
 	}
+	lines = append(lines, m.renderActiveRuns()...)
 	if strings.TrimSpace(m.status) != "" {
 		lines = append(lines, "Last action: "+m.status)
 	}
 	return lines
 }
 
+func (m Model) renderActiveRuns() []string {
+	if len(m.active) == 0 {
+		return nil
+	}
+	lines := []string{"Active runs:"}
+	now := time.Now()
+	for _, run := range m.active {
+		lines = append(lines, fmt.Sprintf("  %s %s %s %s (%s)", run.Agent, run.Kind, run.SpecID, run.Status, run.Duration(now)))
+	}
+	return lines
+}
+
 func (m *Model) reloadSummaries() {
 	if m.root == "" {
 		return
@@ -270,41 +326,33 @@ func (m *Model) runResearchForSelected() {
 	}
 	id := m.summaries[m.selected].ID
 	now := time.Now()
-	researchDir := project.ResearchDir(m.root)
-	if err := os.MkdirAll(researchDir, 0o755); err != nil {
-		m.status = "Research failed: " + err.Error()
-		return
-	}
-	researchPath, err := research.Create(researchDir, id, now)
+	researchPath, err := research.CreateForSpec(m.root, id, now)
 	if err != nil {
 		m.status = "Research failed: " + err.Error()
 		return
 	}
-	briefPath, err := writeResearchBrief(m.root, id, researchPath, now)
+	cfg, err := config.LoadFromRoot(m.root)
 	if err != nil {
 		m.status = "Research failed: " + err.Error()
 		return
 	}
-	cfg, err := config.LoadFromRoot(m.root)
+	agentName := defaultAgentName(cfg)
+	profile, err := agents.Resolve(cfg.AgentProfiles(), agentName)
 	if err != nil {
 		m.status = "Research failed: " + err.Error()
 		return
 	}
-	agentName := defaultAgentName(cfg)
-	profile, err := agents.Resolve(agentProfiles(cfg), agentName)
+	briefPath, err := brief.WriteResearch(brief.NewRepo(m.root), id, researchPath, profile.ScanBytes(), now)
 	if err != nil {
 		m.status = "Research failed: " + err.Error()
 		return
 	}
-	launcher := launchAgent
-	if isClaudeProfile(agentName, profile) {
-		launcher = launchSubagent
-	}
-	if err := launcher(profile, briefPath); err != nil {
-		m.status = "agent not found; brief at " + briefPath
+	run, err := m.startRun(runs.Request{Kind: "research", SpecID: id, Agent: agentName, Profile: profile, Brief: briefPath, Target: researchPath})
+	if err != nil {
+		m.status = err.Error() + "; brief at " + briefPath
 		return
 	}
-	m.status = "launched research agent " + agentName
+	m.status = "launched research agent " + agentName + " (run " + run.ID + ")"
 }
 
 func (m *Model) runSuggestionsForSelected() {
@@ -314,41 +362,43 @@ func (m *Model) runSuggestionsForSelected() {
 	}
 	id := m.summaries[m.selected].ID
 	now := time.Now()
-	suggDir := project.SuggestionsDir(m.root)
-	if err := os.MkdirAll(suggDir, 0o755); err != nil {
+	cfg, err := config.LoadFromRoot(m.root)
+	if err != nil {
 		m.status = "Suggestions failed: " + err.Error()
 		return
 	}
-	suggPath, err := suggestions.Create(suggDir, id, now)
+	agentName := defaultAgentName(cfg)
+	profile, err := agents.Resolve(cfg.AgentProfiles(), agentName)
 	if err != nil {
 		m.status = "Suggestions failed: " + err.Error()
 		return
 	}
-	briefPath, err := writeSuggestionBrief(m.root, id, suggPath, now)
-	if err != nil {
+	suggDir := project.SuggestionsDir(m.root)
+	if err := os.MkdirAll(suggDir, 0o755); err != nil {
 		m.status = "Suggestions failed: " + err.Error()
 		return
 	}
-	cfg, err := config.LoadFromRoot(m.root)
+	spec, err := specs.LoadSpec(m.summaries[m.selected].Path)
 	if err != nil {
 		m.status = "Suggestions failed: " + err.Error()
 		return
 	}
-	agentName := defaultAgentName(cfg)
-	profile, err := agents.Resolve(agentProfiles(cfg), agentName)
+	suggPath, err := suggestions.CreateFromSpec(suggDir, spec, agentName, now)
 	if err != nil {
 		m.status = "Suggestions failed: " + err.Error()
 		return
 	}
-	launcher := launchAgent
-	if isClaudeProfile(agentName, profile) {
-		launcher = launchSubagent
+	briefPath, err := brief.WriteSuggest(brief.NewRepo(m.root), id, suggPath, profile.ScanBytes(), now)
+	if err != nil {
+		m.status = "Suggestions failed: " + err.Error()
+		return
 	}
-	if err := launcher(profile, briefPath); err != nil {
-		m.status = "agent not found; brief at " + briefPath
+	run, err := m.startRun(runs.Request{Kind: "suggest", SpecID: id, Agent: agentName, Profile: profile, Brief: briefPath, Target: suggPath})
+	if err != nil {
+		m.status = err.Error() + "; brief at " + briefPath
 		return
 	}
-	m.status = "launched suggestions agent " + agentName
+	m.status = "launched suggestions agent " + agentName + " (run " + run.ID + ")"
 }
 
 func formatCompleteness(spec specs.Spec) string {
@@ -357,12 +407,13 @@ func formatCompleteness(spec specs.Spec) string {
 		summary = "yes"
 	}
 	return fmt.Sprintf(
-		"Completeness: summary %s | req %d | cuj %d | market %d | competitive %d",
+		"Completeness: summary %s | req %d | cuj %d | market %d | competitive %d | oss %d",
 		summary,
 		len(spec.Requirements),
 		len(spec.CriticalUserJourneys),
 		len(spec.MarketResearch),
 		len(spec.CompetitiveLandscape),
+		len(spec.OSSLandscape),
 	)
 }
 
@@ -399,6 +450,21 @@ func formatResearchDetail(spec specs.Spec) string {
 	return "Market: " + market + " | Competitive: " + comp
 }
 
+func formatOSSDetail(spec specs.Spec) string {
+	if len(spec.OSSLandscape) == 0 {
+		return "OSS: none"
+	}
+	item := spec.OSSLandscape[0]
+	label := item.ID
+	if item.Name != "" {
+		label += " " + item.Name
+	}
+	if item.License != "" {
+		label += " (" + item.License + ")"
+	}
+	return "OSS: " + label
+}
+
 func formatWarnings(spec specs.Spec) []string {
 	if len(spec.Metadata.ValidationWarnings) == 0 {
 		return nil
@@ -443,5 +509,5 @@ func padRight(s string, width int) string {
 }
 
 func defaultKeys() string {
-	return "j/k move  / search  tab focus  g interview  r research  p suggestions  s review  ? help  q quit"
+	return "j/k move  / search  tab focus  g interview  r research  p suggestions  s review  u undo  ? help  q quit"
 }
@@ -6,8 +6,12 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/mistakeknot/praude/internal/agents"
+	"github.com/mistakeknot/praude/internal/ingest"
+	"github.com/mistakeknot/praude/internal/runs"
 )
 
 func TestViewIncludesHeaders(t *testing.T) {
@@ -145,6 +149,10 @@ competitive_landscape:
       - path: ".praude/research/PRD-001-20260115-000000.md"
         anchor: "section-2"
         note: "Source"
+oss_landscape:
+  - id: "OSS-001"
+    name: "Toolkit"
+    license: "MIT"
 `
 	if err := os.WriteFile(filepath.Join(root, ".praude", "specs", "PRD-001.yaml"), []byte(spec), 0o644); err != nil {
 		t.Fatal(err)
@@ -162,6 +170,9 @@ competitive_landscape:
 	if !strings.Contains(out, "CUJ:") || !strings.Contains(out, "Market:") || !strings.Contains(out, "Competitive:") {
 		t.Fatalf("expected section details")
 	}
+	if !strings.Contains(out, "OSS-001 Toolkit") {
+		t.Fatalf("expected oss details")
+	}
 }
 
 func TestViewShowsValidationWarnings(t *testing.T) {
@@ -235,20 +246,12 @@ args = []
 		t.Fatal(err)
 	}
 	called := false
-	oldLaunch := launchAgent
-	oldSub := launchSubagent
-	launchAgent = func(p agents.Profile, briefPath string) error {
-		called = true
-		return nil
-	}
-	launchSubagent = func(p agents.Profile, briefPath string) error {
+	oldLaunch := launchRun
+	launchRun = func(root string, req runs.Request) (runs.Run, error) {
 		called = true
-		return nil
+		return runs.Run{ID: "run-1"}, nil
 	}
-	defer func() {
-		launchAgent = oldLaunch
-		launchSubagent = oldSub
-	}()
+	defer func() { launchRun = oldLaunch }()
 	cwd, err := os.Getwd()
 	if err != nil {
 		t.Fatal(err)
@@ -302,20 +305,12 @@ args = []
 		t.Fatal(err)
 	}
 	called := false
-	oldLaunch := launchAgent
-	oldSub := launchSubagent
-	launchAgent = func(p agents.Profile, briefPath string) error {
+	oldLaunch := launchRun
+	launchRun = func(root string, req runs.Request) (runs.Run, error) {
 		called = true
-		return nil
+		return runs.Run{ID: "run-1"}, nil
 	}
-	launchSubagent = func(p agents.Profile, briefPath string) error {
-		called = true
-		return nil
-	}
-	defer func() {
-		launchAgent = oldLaunch
-		launchSubagent = oldSub
-	}()
+	defer func() { launchRun = oldLaunch }()
 	cwd, err := os.Getwd()
 	if err != nil {
 		t.Fatal(err)
@@ -345,3 +340,176 @@ func stripANSI(input string) string {
 	re := regexp.MustCompile(`\x1b\\[[0-9;]*m`)
 	return re.ReplaceAllString(input, "")
 }
+
+func TestViewShowsActiveRuns(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	spec := "id: \"PRD-001\"\ntitle: \"Alpha\"\nsummary: \"First\"\n"
+	if err := os.WriteFile(filepath.Join(root, ".praude", "specs", "PRD-001.yaml"), []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run, err := runs.Create(root, runs.Run{Kind: "research", SpecID: "PRD-001", Agent: "codex"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel()
+	out := stripANSI(m.View())
+	if !strings.Contains(out, "Active runs:") || !strings.Contains(out, "codex research PRD-001 pending") {
+		t.Fatalf("expected active run in view, got %q", out)
+	}
+	if err := os.Remove(run.Path); err != nil {
+		t.Fatal(err)
+	}
+	if out := stripANSI(m.View()); !strings.Contains(out, "Active runs:") {
+		t.Fatalf("expected the view to render cached runs without reloading, got %q", out)
+	}
+	updated, _ := m.Update(runsTickMsg{})
+	if out := stripANSI(updated.(Model).View()); strings.Contains(out, "Active runs:") {
+		t.Fatalf("expected the tick to refresh active runs, got %q", out)
+	}
+}
+
+func TestRunsTickReportsFailedRun(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	run, err := runs.Create(root, runs.Run{Kind: "research", SpecID: "PRD-001", Agent: "codex"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel()
+	updated, _ := m.Update(runsTickMsg{})
+	m = updated.(Model)
+	if m.status != "" {
+		t.Fatalf("expected no status while running, got %q", m.status)
+	}
+	code := 2
+	run.Status = runs.StatusFailed
+	run.ExitCode = &code
+	if err := runs.Save(run); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(run.Log, []byte("a\nb\nc\nd\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	updated, _ = m.Update(runsTickMsg{})
+	m = updated.(Model)
+	want := "Run " + run.ID + " failed (exit 2): b | c | d"
+	if m.status != want {
+		t.Fatalf("expected %q, got %q", want, m.status)
+	}
+	updated, _ = m.Update(runsTickMsg{})
+	if updated.(Model).status != want {
+		t.Fatalf("expected status to persist")
+	}
+}
+
+func TestRunsTickReportsIngestedSuggestions(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	run, err := runs.Create(root, runs.Run{Kind: "suggest", SpecID: "PRD-001", Agent: "codex"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel()
+	updated, _ := m.Update(runsTickMsg{})
+	m = updated.(Model)
+	code := 0
+	run.Status = runs.StatusSucceeded
+	run.ExitCode = &code
+	run.Ingest = &ingest.Report{Kind: "suggest", SpecID: "PRD-001", Artifact: "PRD-001-x.md", Status: ingest.StatusReady, Summary: "2 suggested changes"}
+	run.IngestHash = "abc"
+	if err := runs.Save(run); err != nil {
+		t.Fatal(err)
+	}
+	updated, _ = m.Update(runsTickMsg{})
+	m = updated.(Model)
+	want := "Suggestions for PRD-001 ready for review: 2 suggested changes (validation: 0 errors, 0 warnings)"
+	if m.status != want {
+		t.Fatalf("expected %q, got %q", want, m.status)
+	}
+}
+
+func TestInteractiveAgentAttachesToTerminal(t *testing.T) {
+	oldPrepare, oldLaunch := prepareRun, launchRun
+	defer func() { prepareRun, launchRun = oldPrepare, oldLaunch }()
+	launchRun = func(root string, req runs.Request) (runs.Run, error) {
+		t.Fatalf("interactive agents must not be launched in the background")
+		return runs.Run{}, nil
+	}
+	prepareRun = func(root string, req runs.Request) (runs.Run, error) {
+		return runs.Run{ID: "run-1", Interactive: true}, nil
+	}
+	m := Model{root: t.TempDir(), mode: "list"}
+	if _, err := m.startRun(runs.Request{Kind: "research", Agent: "claude", Profile: agents.Profile{Command: "claude", Mode: agents.ModeInteractive}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.watching["run-1"]; !ok || m.attach == nil {
+		t.Fatalf("expected pending attach for run-1")
+	}
+	updated, cmd := m.Update(tea.WindowSizeMsg{Width: 100})
+	if cmd == nil || updated.(Model).attach != nil {
+		t.Fatalf("expected update to hand the terminal to the agent")
+	}
+}
+
+func TestRunsTickReportsSandboxDiscards(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	run, err := runs.Create(root, runs.Run{Kind: "suggest", SpecID: "PRD-001", Agent: "codex", Sandbox: "copy"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel()
+	updated, _ := m.Update(runsTickMsg{})
+	m = updated.(Model)
+	run.Status = runs.StatusSucceeded
+	run.Discarded = []string{"M .praude/specs/PRD-002.yaml"}
+	if err := runs.Save(run); err != nil {
+		t.Fatal(err)
+	}
+	updated, _ = m.Update(runsTickMsg{})
+	m = updated.(Model)
+	if !strings.Contains(m.status, "discarded 1 change(s) outside the artifact") || !strings.Contains(m.status, run.ID) {
+		t.Fatalf("expected discard status, got %q", m.status)
+	}
+}
@@ -6,7 +6,8 @@ func renderHelpOverlay() string {
 	lines := []string{
 		"Help",
 		"j/k: move  /: search",
-		"g: interview  r: research  p: suggestions  s: review",
+		"g: interview  r: research  p: suggestions  s: review  u: undo apply",
+		"c: compare pending suggestions (in file picker)",
 		"?: help  `: tutorial  q: quit",
 		"Esc: close",
 	}
@@ -1,24 +1,44 @@
 package tui
 
 import (
+	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/mistakeknot/praude/internal/agents"
+	"github.com/mistakeknot/praude/internal/brief"
+	"github.com/mistakeknot/praude/internal/config"
 	"github.com/mistakeknot/praude/internal/git"
 	"github.com/mistakeknot/praude/internal/project"
+	"github.com/mistakeknot/praude/internal/runs"
+	"github.com/mistakeknot/praude/internal/specs"
 	"github.com/mistakeknot/praude/internal/suggestions"
 )
 
 type suggestionsState struct {
-	active             bool
-	id                 string
-	path               string
-	sugg               suggestions.Suggestion
-	err                string
-	acceptSummary      bool
-	acceptRequirements bool
-	acceptCUJ          bool
-	acceptMarket       bool
-	acceptCompetitive  bool
+	active     bool
+	id         string
+	path       string
+	sugg       suggestions.Suggestion
+	items      []suggestions.Item
+	accepted   map[string]bool
+	cursor     int
+	conflicted map[string]bool
+	err        string
+	current    specs.Spec
+	showDiff   bool
+	diff       []string
+	scroll     int
+	files      []suggestions.File
+	picking    bool
+	pick       int
+	feedback   bool
+	asking     []string
+	reasons    map[string]string
+	comparing  bool
+	compare    []string
 }
 
 func (m *Model) enterSuggestions() {
@@ -26,25 +46,247 @@ func (m *Model) enterSuggestions() {
 		return
 	}
 	id := m.summaries[m.selected].ID
-	dir := project.SuggestionsDir(m.root)
-	sugg, path, err := suggestions.LoadLatest(dir, id)
+	m.mode = "suggestions"
+	files, err := suggestions.List(project.SuggestionsDir(m.root), id)
+	if err == nil && len(files) == 0 {
+		err = fmt.Errorf("no suggestions for %s", id)
+	}
 	if err != nil {
 		m.suggestions = suggestionsState{active: true, id: id, err: err.Error()}
-		m.mode = "suggestions"
 		return
 	}
+	if len(files) == 1 {
+		m.openSuggestionFile(id, files[0])
+		return
+	}
+	m.suggestions = suggestionsState{active: true, id: id, files: files, picking: true, pick: len(files) - 1}
+}
+
+func (m *Model) handlePickerKey(key string) {
+	state := &m.suggestions
+	if state.comparing {
+		switch key {
+		case "c", "esc":
+			state.comparing = false
+			state.scroll = 0
+		case "j", "down":
+			if state.scroll < len(state.compare)-1 {
+				state.scroll++
+			}
+		case "k", "up":
+			if state.scroll > 0 {
+				state.scroll--
+			}
+		}
+		return
+	}
+	switch key {
+	case "c":
+		m.startComparison()
+	case "j", "down":
+		if state.pick < len(state.files)-1 {
+			state.pick++
+		}
+	case "k", "up":
+		if state.pick > 0 {
+			state.pick--
+		}
+	case "enter":
+		m.openSuggestionFile(state.id, state.files[state.pick])
+	}
+}
+
+func (m *Model) startComparison() {
+	state := &m.suggestions
+	var pending []suggestions.File
+	for _, file := range state.files {
+		if file.Err == nil && file.Status() == suggestions.StatusPending {
+			pending = append(pending, file)
+		}
+	}
+	if len(pending) < 2 {
+		m.status = "Need at least two pending suggestion files to compare"
+		return
+	}
+	current, _ := specs.LoadSpec(m.summaries[m.selected].Path)
+	labels := suggestions.CompareLabels(pending)
+	lines := []string{}
+	for _, file := range pending {
+		lines = append(lines, "  "+labels[file.Name]+"  "+file.Name)
+	}
+	sections := suggestions.Compare(pending, current)
+	if len(sections) == 0 {
+		lines = append(lines, "No suggested changes.")
+	}
+	state.compare = append(lines, suggestions.RenderComparison(sections)...)
+	state.comparing = true
+	state.scroll = 0
+}
+
+func (m *Model) openSuggestionFile(id string, file suggestions.File) {
+	if file.Err != nil {
+		m.suggestions = suggestionsState{active: true, id: id, err: file.Err.Error()}
+		return
+	}
+	sugg := file.Suggestion
+	current, _ := specs.LoadSpec(m.summaries[m.selected].Path)
+	items := suggestions.Items(sugg, current)
+	accepted := make(map[string]bool)
+	for _, item := range items {
+		accepted[item.Key()] = true
+	}
+	conflicts, err := suggestions.CheckConflicts(project.SuggestionsDir(m.root), sugg, current)
+	conflicted := make(map[string]bool)
+	for _, item := range conflicts {
+		conflicted[item.Key()] = true
+		accepted[item.Key()] = false
+	}
 	m.suggestions = suggestionsState{
-		active:             true,
-		id:                 id,
-		path:               path,
-		sugg:               sugg,
-		acceptSummary:      sugg.Summary != "",
-		acceptRequirements: len(sugg.Requirements) > 0,
-		acceptCUJ:          len(sugg.CriticalUserJourneys) > 0,
-		acceptMarket:       len(sugg.MarketResearch) > 0,
-		acceptCompetitive:  len(sugg.CompetitiveLandscape) > 0,
+		active:     true,
+		id:         id,
+		path:       file.Path,
+		sugg:       sugg,
+		items:      items,
+		accepted:   accepted,
+		conflicted: conflicted,
+		current:    current,
+	}
+	if err != nil {
+		m.status = "cannot check for conflicts: " + err.Error()
 	}
-	m.mode = "suggestions"
+}
+
+func (m Model) renderPicker() []string {
+	if m.suggestions.comparing {
+		lines := []string{"Comparison", "Spec: " + m.suggestions.id}
+		lines = append(lines, m.suggestions.compare[m.suggestions.scroll:]...)
+		return append(lines, "[j/k] scroll  [c] back to files  [q] quit")
+	}
+	lines := []string{"Suggestion files", "Spec: " + m.suggestions.id}
+	for i, file := range m.suggestions.files {
+		prefix := "  "
+		if i == m.suggestions.pick {
+			prefix = "> "
+		}
+		agent := file.Suggestion.Agent
+		if agent == "" {
+			agent = "-"
+		}
+		stamp := "-"
+		if !file.CreatedAt.IsZero() {
+			stamp = file.CreatedAt.UTC().Format("2006-01-02 15:04")
+		}
+		lines = append(lines, prefix+file.Name+"  "+agent+"  "+stamp+"  "+string(file.Status()))
+	}
+	lines = append(lines, "[j/k] move  [enter] open  [c] compare  [q] quit")
+	return lines
+}
+
+func (m *Model) handleSuggestionsKey(key string) {
+	state := &m.suggestions
+	if key == "d" {
+		state.showDiff = !state.showDiff
+		state.scroll = 0
+		if state.showDiff {
+			state.diff = m.previewDiff()
+		}
+		return
+	}
+	if state.showDiff {
+		switch key {
+		case "j", "down":
+			if state.scroll < len(state.diff)-1 {
+				state.scroll++
+			}
+		case "k", "up":
+			if state.scroll > 0 {
+				state.scroll--
+			}
+		}
+		return
+	}
+	switch key {
+	case "j", "down":
+		if state.cursor < len(state.items)-1 {
+			state.cursor++
+		}
+	case "k", "up":
+		if state.cursor > 0 {
+			state.cursor--
+		}
+	case " ", "space":
+		if state.cursor < len(state.items) {
+			item := state.items[state.cursor]
+			state.accepted[item.Key()] = !state.accepted[item.Key()]
+		}
+	case "tab":
+		state.jumpSection(1)
+	case "shift+tab":
+		state.jumpSection(-1)
+	case "t":
+		if state.cursor < len(state.items) {
+			state.toggleSection(state.items[state.cursor].Section)
+		}
+	}
+}
+
+func (s *suggestionsState) jumpSection(step int) {
+	sections := s.sections()
+	if len(sections) == 0 || s.cursor >= len(s.items) {
+		return
+	}
+	current := 0
+	for i, section := range sections {
+		if section == s.items[s.cursor].Section {
+			current = i
+		}
+	}
+	target := sections[(current+step+len(sections))%len(sections)]
+	for idx, item := range s.items {
+		if item.Section == target {
+			s.cursor = idx
+			return
+		}
+	}
+}
+
+func (s suggestionsState) sections() []string {
+	var out []string
+	for _, key := range suggestions.SectionKeys() {
+		for _, item := range s.items {
+			if item.Section == key {
+				out = append(out, key)
+				break
+			}
+		}
+	}
+	return out
+}
+
+func (s *suggestionsState) toggleSection(section string) {
+	selected := false
+	for _, item := range s.items {
+		if item.Section == section && s.accepted[item.Key()] {
+			selected = true
+		}
+	}
+	for _, item := range s.items {
+		if item.Section == section {
+			s.accepted[item.Key()] = !selected
+		}
+	}
+}
+
+func (m *Model) previewDiff() []string {
+	state := m.suggestions
+	selected := suggestions.Select(state.sugg, func(item suggestions.Item) bool {
+		return state.accepted[item.Key()]
+	})
+	lines, err := suggestions.RenderDiff(state.current, selected, config.LoadValidationOptions(m.root))
+	if err != nil {
+		return append(lines, "Diff failed: "+err.Error())
+	}
+	return lines
 }
 
 func (m *Model) applySuggestions() {
@@ -52,65 +294,218 @@ func (m *Model) applySuggestions() {
 		return
 	}
 	specPath := filepath.Join(project.SpecsDir(m.root), m.suggestions.id+".yaml")
-	selected := suggestions.Suggestion{}
-	if m.suggestions.acceptSummary {
-		selected.Summary = m.suggestions.sugg.Summary
+	selected := suggestions.Select(m.suggestions.sugg, func(item suggestions.Item) bool {
+		return m.suggestions.accepted[item.Key()]
+	})
+	previous, err := os.ReadFile(specPath)
+	if err != nil {
+		m.status = "Suggestions not applied: " + err.Error()
+		return
+	}
+	delta, err := suggestions.Apply(specPath, selected, config.LoadValidationOptions(m.root))
+	if err != nil {
+		m.status = "Suggestions not applied: " + err.Error()
+		return
+	}
+	m.status = "Applied suggestions for " + m.suggestions.id + " (validation: " + itoa(len(delta.New)) + " new, " + itoa(len(delta.Resolved)) + " resolved)"
+	keep := func(item suggestions.Item) bool { return m.suggestions.accepted[item.Key()] }
+	reviewer, now := suggestions.Reviewer(m.root), time.Now()
+	if err := suggestions.RecordReview(m.suggestions.path, m.suggestions.items, keep, reviewer, now); err != nil {
+		m.suggestions.err = err.Error()
+		return
 	}
-	if m.suggestions.acceptRequirements {
-		selected.Requirements = m.suggestions.sugg.Requirements
+	suggDir := project.SuggestionsDir(m.root)
+	histPath, err := suggestions.RecordApply(suggDir, m.suggestions.id, specPath, m.suggestions.path, previous, suggestions.AppliedSections(m.suggestions.items, keep), reviewer, now)
+	if err != nil {
+		m.status = "Applied suggestions but could not record undo history: " + err.Error()
 	}
-	if m.suggestions.acceptCUJ {
-		selected.CriticalUserJourneys = m.suggestions.sugg.CriticalUserJourneys
+	files := []string{specPath, m.suggestions.path}
+	if histPath != "" {
+		files = append(files, histPath)
 	}
-	if m.suggestions.acceptMarket {
-		selected.MarketResearch = m.suggestions.sugg.MarketResearch
+	if err := git.EnsureRepo(m.root); err == nil {
+		_ = git.CommitFiles(m.root, files, "chore(praude): apply suggestions "+m.suggestions.id)
 	}
-	if m.suggestions.acceptCompetitive {
-		selected.CompetitiveLandscape = m.suggestions.sugg.CompetitiveLandscape
+	m.reloadSummaries()
+}
+
+func (m *Model) undoSuggestionsForSelected() {
+	if len(m.summaries) == 0 {
+		m.status = "No PRD selected"
+		return
 	}
-	if err := suggestions.Apply(specPath, selected); err != nil {
-		m.suggestions.err = err.Error()
+	summary := m.summaries[m.selected]
+	suggDir := project.SuggestionsDir(m.root)
+	rec, err := suggestions.Undo(suggDir, summary.ID, summary.Path, false, suggestions.Reviewer(m.root), time.Now())
+	if err != nil {
+		m.status = "Undo failed: " + err.Error()
 		return
 	}
 	if err := git.EnsureRepo(m.root); err == nil {
-		_ = git.CommitFiles(m.root, []string{specPath}, "chore(praude): apply suggestions "+m.suggestions.id)
+		_ = git.CommitFiles(m.root, []string{summary.Path, filepath.Join(suggDir, rec.Suggestion), rec.Path}, "chore(praude): undo suggestions "+summary.ID)
 	}
+	m.status = "Reverted suggestions " + rec.Suggestion + " for " + summary.ID
 	m.reloadSummaries()
 }
 
+func (m *Model) startRejection() {
+	state := &m.suggestions
+	if state.path == "" {
+		m.mode = "list"
+		return
+	}
+	state.asking = state.sections()
+	state.reasons = make(map[string]string)
+	if len(state.asking) == 0 {
+		m.rejectSuggestions()
+		m.mode = "list"
+		return
+	}
+	state.feedback = true
+	m.input = ""
+}
+
+func (m *Model) handleFeedbackKey(key string) {
+	state := &m.suggestions
+	if key == "esc" {
+		state.feedback = false
+		m.input = ""
+		return
+	}
+	m.handleTextStep(key, func(reason string) {
+		state.reasons[state.asking[0]] = reason
+		state.asking = state.asking[1:]
+	})
+	if len(state.asking) == 0 {
+		state.feedback = false
+		m.rejectSuggestions()
+		m.mode = "list"
+	}
+}
+
+func (m *Model) rejectSuggestions() {
+	state := m.suggestions
+	if state.path == "" {
+		return
+	}
+	now := time.Now()
+	var rerun *runs.Request
+	for _, reason := range state.reasons {
+		if reason != "" {
+			req, err := m.followUpRequest(now)
+			if err != nil {
+				m.status = "Reject failed: " + err.Error()
+				return
+			}
+			rerun = &req
+			break
+		}
+	}
+	if err := suggestions.RecordRejection(state.path, state.items, state.reasons, suggestions.Reviewer(m.root), now); err != nil {
+		m.status = "Reject failed: " + err.Error()
+		return
+	}
+	if err := git.EnsureRepo(m.root); err == nil {
+		_ = git.CommitFiles(m.root, []string{state.path}, "chore(praude): reject suggestions "+state.id)
+	}
+	m.status = "Rejected suggestions for " + state.id
+	if rerun == nil {
+		return
+	}
+	run, err := m.startRun(*rerun)
+	if err != nil {
+		m.status = err.Error() + "; brief at " + rerun.Brief
+		return
+	}
+	m.status = "relaunched suggestions agent " + rerun.Agent + " with feedback (run " + run.ID + ")"
+}
+
+func (m *Model) followUpRequest(now time.Time) (runs.Request, error) {
+	state := m.suggestions
+	cfg, err := config.LoadFromRoot(m.root)
+	if err != nil {
+		return runs.Request{}, err
+	}
+	profiles := cfg.AgentProfiles()
+	agentName := state.sugg.Agent
+	if _, ok := profiles[agentName]; !ok {
+		agentName = defaultAgentName(cfg)
+	}
+	profile, err := agents.Resolve(profiles, agentName)
+	if err != nil {
+		return runs.Request{}, err
+	}
+	spec, err := specs.LoadSpec(m.summaries[m.selected].Path)
+	if err != nil {
+		return runs.Request{}, err
+	}
+	suggPath, err := suggestions.CreateFollowUp(project.SuggestionsDir(m.root), spec, agentName, filepath.Base(state.path), now)
+	if err != nil {
+		return runs.Request{}, err
+	}
+	briefPath, err := brief.WriteFeedback(brief.NewRepo(m.root), state.id, state.path, suggPath, state.reasons, profile.ScanBytes(), now)
+	if err != nil {
+		_ = os.Remove(suggPath)
+		return runs.Request{}, err
+	}
+	return runs.Request{Kind: "suggest", SpecID: state.id, Agent: agentName, Profile: profile, Brief: briefPath, Target: suggPath}, nil
+}
+
 func (m *Model) renderSuggestions() []string {
+	if m.suggestions.picking {
+		return m.renderPicker()
+	}
 	lines := []string{"Suggestions"}
 	if m.suggestions.err != "" {
 		lines = append(lines, "Error: "+m.suggestions.err)
 		return lines
 	}
 	lines = append(lines, "Spec: "+m.suggestions.id)
-	lines = append(lines, "1 Summary: "+toggleLabel(m.suggestions.acceptSummary, m.suggestions.sugg.Summary))
-	lines = append(lines, "2 Requirements: "+countToggle(m.suggestions.acceptRequirements, len(m.suggestions.sugg.Requirements)))
-	lines = append(lines, "3 CUJ: "+countToggle(m.suggestions.acceptCUJ, len(m.suggestions.sugg.CriticalUserJourneys)))
-	lines = append(lines, "4 Market: "+countToggle(m.suggestions.acceptMarket, len(m.suggestions.sugg.MarketResearch)))
-	lines = append(lines, "5 Competitive: "+countToggle(m.suggestions.acceptCompetitive, len(m.suggestions.sugg.CompetitiveLandscape)))
-	lines = append(lines, "[1-5] toggle  [a] accept  [r] reject  [q] quit")
-	return lines
-}
-
-func toggleLabel(accepted bool, summary string) string {
-	if summary == "" {
-		return "[none]"
+	if m.suggestions.feedback {
+		section := m.suggestions.asking[0]
+		lines = append(lines,
+			"Why reject "+suggestions.SectionTitle(section)+"? (enter to continue, empty to skip, esc to cancel)",
+			"> "+m.input,
+		)
+		return lines
 	}
-	if accepted {
-		return "[accept] " + summary
+	if n := len(m.suggestions.conflicted); n > 0 {
+		lines = append(lines, "Conflicts: "+itoa(n)+" items conflict with the current spec (skipped unless toggled)")
+	}
+	if m.suggestions.showDiff {
+		lines = append(lines, m.suggestions.diff[m.suggestions.scroll:]...)
+		lines = append(lines, "[j/k] scroll  [d] back  [a] accept  [r] reject  [q] quit")
+		return lines
+	}
+	sections := m.suggestions.sections()
+	if len(sections) == 0 {
+		lines = append(lines, "[none]")
 	}
-	return "[skip] " + summary
+	for _, section := range sections {
+		lines = append(lines, suggestions.SectionTitle(section))
+		for idx, item := range m.suggestions.items {
+			if item.Section != section {
+				continue
+			}
+			prefix := "  "
+			if idx == m.suggestions.cursor {
+				prefix = "> "
+			}
+			line := prefix + itemToggle(m.suggestions.accepted[item.Key()], item)
+			if m.suggestions.conflicted[item.Key()] {
+				line += " [conflict]"
+			}
+			lines = append(lines, line)
+		}
+	}
+	lines = append(lines, "[j/k] move  [space] toggle  [tab] next section  [t] toggle section  [d] diff  [a] accept  [r] reject  [q] quit")
+	return lines
 }
 
-func countToggle(accepted bool, count int) string {
-	if count == 0 {
-		return "[none]"
-	}
+func itemToggle(accepted bool, item suggestions.Item) string {
 	label := "[skip]"
 	if accepted {
 		label = "[accept]"
 	}
-	return label + " " + itoa(count)
+	return label + " " + string(item.Action) + " " + strings.TrimSpace(item.Label)
 }
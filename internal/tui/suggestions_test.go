@@ -8,6 +8,8 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mistakeknot/praude/internal/runs"
+	"github.com/mistakeknot/praude/internal/specs"
 	"github.com/mistakeknot/praude/internal/suggestions"
 )
 
@@ -32,7 +34,7 @@ func TestSuggestionAcceptAppliesUpdate(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	updatedBody := strings.Replace(string(raw), "suggestion: \"\"", "suggestion: \"Updated summary\"", 1)
+	updatedBody := strings.Replace(string(raw), "# summary: \"\"", "summary: \"Updated summary\"", 1)
 	if err := os.WriteFile(suggPath, []byte(updatedBody), 0o644); err != nil {
 		t.Fatal(err)
 	}
@@ -77,7 +79,7 @@ func TestSuggestionToggleRejectSkipsUpdate(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	updatedBody := strings.Replace(string(raw), "suggestion: \"\"", "suggestion: \"Updated summary\"", 1)
+	updatedBody := strings.Replace(string(raw), "# summary: \"\"", "summary: \"Updated summary\"", 1)
 	if err := os.WriteFile(suggPath, []byte(updatedBody), 0o644); err != nil {
 		t.Fatal(err)
 	}
@@ -91,7 +93,7 @@ func TestSuggestionToggleRejectSkipsUpdate(t *testing.T) {
 	}
 	m := NewModel()
 	m = pressKeySuggestion(m, "s")
-	m = pressKeySuggestion(m, "1")
+	m = pressKeySuggestion(m, "t")
 	m = pressKeySuggestion(m, "a")
 	updatedSpec, err := os.ReadFile(specPath)
 	if err != nil {
@@ -104,9 +106,380 @@ func TestSuggestionToggleRejectSkipsUpdate(t *testing.T) {
 
 func pressKeySuggestion(m Model, key string) Model {
 	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
-	if key == "enter" {
+	switch key {
+	case "enter":
 		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case "tab":
+		msg = tea.KeyMsg{Type: tea.KeyTab}
+	case "shift+tab":
+		msg = tea.KeyMsg{Type: tea.KeyShiftTab}
 	}
 	updated, _ := m.Update(msg)
 	return updated.(Model)
 }
+
+func TestSuggestionSectionToggleReachesLaterSections(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	base := specs.Spec{ID: "PRD-001", Title: "Title", Summary: "Old", Priority: 3, EstimatedMinutes: 30}
+	specPath := filepath.Join(root, ".praude", "specs", "PRD-001.yaml")
+	if err := specs.SaveSpec(specPath, base); err != nil {
+		t.Fatal(err)
+	}
+	suggPath, err := suggestions.CreateFromSpec(filepath.Join(root, ".praude", "suggestions"), base, "codex", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(suggPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(suggPath, []byte(strings.ReplaceAll(string(raw), "\n# ", "\n")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel()
+	m = pressKeySuggestion(m, "s")
+	if n := len(m.suggestions.sections()); n < 10 {
+		t.Fatalf("expected at least ten sections, got %d", n)
+	}
+	m = pressKeySuggestion(m, "shift+tab")
+	if section := m.suggestions.items[m.suggestions.cursor].Section; section != suggestions.SectionPriority {
+		t.Fatalf("expected shift+tab to wrap to the priority section, got %s", section)
+	}
+	m = pressKeySuggestion(m, "t")
+	m = pressKeySuggestion(m, "a")
+	got, err := specs.LoadSpec(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Priority != 3 || got.EstimatedMinutes != 0 {
+		t.Fatalf("expected only the priority section skipped, got priority %d estimate %d", got.Priority, got.EstimatedMinutes)
+	}
+}
+
+func TestSuggestionItemToggleSkipsSingleItem(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "suggestions"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	specPath := filepath.Join(root, ".praude", "specs", "PRD-001.yaml")
+	specRaw := []byte("id: \"PRD-001\"\ntitle: \"Title\"\nsummary: \"Old\"\nrequirements:\n  - \"REQ-001: Existing\"\n")
+	if err := os.WriteFile(specPath, specRaw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	body := `# Suggestions for PRD-001
+
+## Requirements
+- status: pending
+- suggestion:
+  - "REQ-002: Skipped"
+  - "REQ-003: Accepted"
+`
+	if err := os.WriteFile(filepath.Join(root, ".praude", "suggestions", "PRD-001-20260115-000000.md"), []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel()
+	m = pressKeySuggestion(m, "s")
+	m = pressKeySuggestion(m, " ")
+	if !strings.Contains(strings.Join(m.renderSuggestions(), "\n"), "> [skip] add REQ-002: Skipped") {
+		t.Fatalf("expected first item skipped in view")
+	}
+	m = pressKeySuggestion(m, "a")
+	updatedSpec, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(updatedSpec)
+	if strings.Contains(content, "REQ-002") || !strings.Contains(content, "REQ-003: Accepted") || !strings.Contains(content, "REQ-001: Existing") {
+		t.Fatalf("expected per-item merge, got %q", content)
+	}
+}
+
+func TestSuggestionDiffPaneShowsPreview(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "suggestions"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	specPath := filepath.Join(root, ".praude", "specs", "PRD-001.yaml")
+	specRaw := []byte("id: \"PRD-001\"\ntitle: \"Title\"\nsummary: \"Old\"\n")
+	if err := os.WriteFile(specPath, specRaw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	body := `# Suggestions for PRD-001
+
+## Summary
+- status: pending
+- suggestion: "Updated summary"
+`
+	if err := os.WriteFile(filepath.Join(root, ".praude", "suggestions", "PRD-001-20260115-000000.md"), []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel()
+	m = pressKeySuggestion(m, "s")
+	m = pressKeySuggestion(m, "d")
+	out := strings.Join(m.renderSuggestions(), "\n")
+	if !strings.Contains(out, "- summary: Old") || !strings.Contains(out, "+ summary: Updated summary") {
+		t.Fatalf("expected summary diff, got %q", out)
+	}
+	if !strings.Contains(out, "Validation after apply") {
+		t.Fatalf("expected validation result, got %q", out)
+	}
+	m = pressKeySuggestion(m, "j")
+	if m.suggestions.scroll != 1 {
+		t.Fatalf("expected diff to scroll")
+	}
+	m = pressKeySuggestion(m, "d")
+	if m.suggestions.showDiff {
+		t.Fatalf("expected diff pane closed")
+	}
+}
+
+func TestSuggestionPickerOpensChosenFile(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	suggDir := filepath.Join(root, ".praude", "suggestions")
+	if err := os.MkdirAll(suggDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	specPath := filepath.Join(root, ".praude", "specs", "PRD-001.yaml")
+	if err := os.WriteFile(specPath, []byte("id: \"PRD-001\"\ntitle: \"Title\"\nsummary: \"Old\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for i, summary := range []string{"First", "Second"} {
+		body := "# Suggestions for PRD-001\n\n## Summary\n- status: pending\n- suggestion: \"" + summary + "\"\n"
+		name := "PRD-001-20260115-00000" + itoa(i) + ".md"
+		if err := os.WriteFile(filepath.Join(suggDir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel()
+	m = pressKeySuggestion(m, "s")
+	out := strings.Join(m.renderSuggestions(), "\n")
+	if !strings.Contains(out, "PRD-001-20260115-000000.md") || !strings.Contains(out, "> PRD-001-20260115-000001.md") {
+		t.Fatalf("expected picker with latest selected, got %q", out)
+	}
+	m = pressKeySuggestion(m, "k")
+	m = pressKeySuggestion(m, "enter")
+	m = pressKeySuggestion(m, "a")
+	raw, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw), "First") {
+		t.Fatalf("expected chosen file applied, got %q", string(raw))
+	}
+}
+
+func TestSuggestionUndoRestoresSpec(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "suggestions"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	specPath := filepath.Join(root, ".praude", "specs", "PRD-001.yaml")
+	specRaw := []byte("id: \"PRD-001\"\ntitle: \"Title\"\nsummary: \"Old\"\n")
+	if err := os.WriteFile(specPath, specRaw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	suggPath, err := suggestions.Create(filepath.Join(root, ".praude", "suggestions"), "PRD-001", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(suggPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	updatedBody := strings.Replace(string(raw), "# summary: \"\"", "summary: \"Updated summary\"", 1)
+	if err := os.WriteFile(suggPath, []byte(updatedBody), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel()
+	m = pressKeySuggestion(m, "s")
+	m = pressKeySuggestion(m, "a")
+	m = pressKeySuggestion(m, "u")
+	restored, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(restored) != string(specRaw) {
+		t.Fatalf("expected original spec restored, got %q (status %q)", restored, m.status)
+	}
+	if status := suggestions.Open(suggPath).Status(); status != suggestions.StatusReverted {
+		t.Fatalf("expected reverted status, got %s", status)
+	}
+}
+
+func TestSuggestionRejectWithReasonRelaunchesAgent(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	suggDir := filepath.Join(root, ".praude", "suggestions")
+	if err := os.MkdirAll(suggDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := "validation_mode = \"soft\"\n\n[agents.codex]\ncommand = \"codex\"\nargs = []\n"
+	if err := os.WriteFile(filepath.Join(root, ".praude", "config.toml"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	spec := specs.Spec{ID: "PRD-001", Title: "Title", Summary: "Old"}
+	if err := specs.SaveSpec(filepath.Join(root, ".praude", "specs", "PRD-001.yaml"), spec); err != nil {
+		t.Fatal(err)
+	}
+	suggPath, err := suggestions.CreateFromSpec(suggDir, spec, "codex", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(suggPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(suggPath, []byte(strings.Replace(string(raw), "# summary: \"\"", "summary: \"Vague\"", 1)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var launched string
+	oldLaunch := launchRun
+	launchRun = func(root string, req runs.Request) (runs.Run, error) {
+		launched = req.Brief
+		return runs.Run{ID: "run-1"}, nil
+	}
+	defer func() { launchRun = oldLaunch }()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel()
+	m = pressKeySuggestion(m, "s")
+	m = pressKeySuggestion(m, "r")
+	if !strings.Contains(m.View(), "Why reject Summary?") {
+		t.Fatalf("expected reason prompt, got %q", m.View())
+	}
+	m = typeAndEnter(m, "too vague")
+	for i := 0; i < len(suggestions.SectionKeys()) && m.suggestions.feedback; i++ {
+		m = pressKey(m, "enter")
+	}
+	if m.mode != "list" {
+		t.Fatalf("expected list mode after feedback, got %s", m.mode)
+	}
+	if launched == "" {
+		t.Fatalf("expected agent relaunch, status %q", m.status)
+	}
+	briefRaw, err := os.ReadFile(launched)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(briefRaw), "- Summary: too vague") || !strings.Contains(string(briefRaw), "Vague") {
+		t.Fatalf("expected feedback and rejected suggestion in brief, got %q", briefRaw)
+	}
+	rejected := suggestions.Open(suggPath)
+	if rejected.Status() != suggestions.StatusRejected || rejected.Suggestion.Reviews[suggestions.SectionSummary].Reason != "too vague" {
+		t.Fatalf("expected rejection with reason, got %+v", rejected.Suggestion.Reviews[suggestions.SectionSummary])
+	}
+	files, err := suggestions.List(suggDir, "PRD-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || files[1].Suggestion.Parent != filepath.Base(suggPath) {
+		t.Fatalf("expected follow-up linked to %s, got %+v", filepath.Base(suggPath), files)
+	}
+}
+
+func TestSuggestionPickerComparesPendingFiles(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".praude", "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	suggDir := filepath.Join(root, ".praude", "suggestions")
+	if err := os.MkdirAll(suggDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	specPath := filepath.Join(root, ".praude", "specs", "PRD-001.yaml")
+	if err := os.WriteFile(specPath, []byte("id: \"PRD-001\"\ntitle: \"Title\"\nsummary: \"Old\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for i, agent := range []string{"codex", "claude"} {
+		body := "---\nformat: 2\nid: \"PRD-001\"\nagent: \"" + agent + "\"\n---\n## Summary\n~~~yaml\nsummary: \"From " + agent + "\"\n~~~\n"
+		name := "PRD-001-20260115-00000" + itoa(i) + ".md"
+		if err := os.WriteFile(filepath.Join(suggDir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel()
+	m = pressKeySuggestion(m, "s")
+	m = pressKeySuggestion(m, "c")
+	out := strings.Join(m.renderSuggestions(), "\n")
+	for _, want := range []string{"codex", "From codex", "claude", "From claude"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in comparison, got %q", want, out)
+		}
+	}
+	m = pressKeySuggestion(m, "c")
+	out = strings.Join(m.renderSuggestions(), "\n")
+	if !strings.Contains(out, "> PRD-001-20260115-000001.md") {
+		t.Fatalf("expected picker after leaving comparison, got %q", out)
+	}
+}